@@ -0,0 +1,57 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//Forward - Execute an activation forward layer
+//
+//	h		MIOpen handle (input)
+//	alpha		Floating point scaling factor, allocated on the host (input)
+//	xD		Tensor descriptor for data input tensor x (input)
+//	x		Data tensor x (input)
+//	beta		Floating point shift factor, allocated on the host (input)
+//	yD		Tensor descriptor for output data tensor y (input)
+//	y		Data tensor y (output)
+func (a *ActivationD) Forward(h *Handle, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	k := check{op: "(*ActivationD)Forward()"}
+	xt, yt := k.tensor("xD", xD, x), k.tensor("yD", yD, y)
+	k.sameshape(xt, yt)
+	k.samedtype(xt, yt)
+	err := k.err
+	if err == nil {
+		err = h.b.ActivationForward(a, alpha, xD, x, beta, yD, y)
+	}
+	return withdesc(err, "a", a, "xD", xD, "yD", yD)
+}
+
+//Backward - Execute a activation backwards layer
+//
+//	h		MIOpen handle (input)
+//	alpha		Floating point scaling factor, allocated on the host (input)
+//	yD		Tensor descriptor for input data tensor y (input)
+//	y		Data tensor y (input)
+//	dyD		Tensor descriptor for input data tensor dy (input)
+//	dy		Data delta tensor dy (input)
+//	xD		Tensor descriptor for data input tensor x (input)
+//	x		Data tensor x (input)
+//	beta		Floating point shift factor, allocated on the host (input)
+//	dxD		Tensor descriptor for data output tensor dx (input)
+//	dx		Output data delta tensor dx (output)
+func (a *ActivationD) Backward(h *Handle, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	k := check{op: "(*ActivationD)Backward()"}
+	yt, dyt, xt, dxt := k.tensor("yD", yD, y), k.tensor("dyD", dyD, dy), k.tensor("xD", xD, x), k.tensor("dxD", dxD, dx)
+	k.sameshape(xt, yt, dyt, dxt)
+	k.samedtype(xt, yt, dyt, dxt)
+	err := k.err
+	if err == nil {
+		err = h.b.ActivationBackward(a, alpha, yD, y, dyD, dy, xD, x, beta, dxD, dx)
+	}
+	return withdesc(err, "a", a, "yD", yD, "dyD", dyD, "xD", xD, "dxD", dxD)
+}
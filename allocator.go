@@ -0,0 +1,48 @@
+package miopen
+
+import (
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//Allocator allocates the device memory MIOpen uses internally, like the scratch memory of Find and of
+//some kernels.  It is set with (*Handle).SetAllocator so those allocations can go through a caching allocator
+//and be counted with the rest of the memory a program uses.
+//
+//Allocate and Free are called from the goroutine running the operation that needs the memory.
+type Allocator interface {
+	Allocate(sib uint) (cutil.Mem, error)
+	Free(m cutil.Mem) error
+}
+
+//HostAllocator returns an Allocator of go memory.  The cpu backend runs on it in either build, and a Tensor made
+//with it can be read and written from go without a device.
+func HostAllocator() Allocator {
+	return hostallocator{}
+}
+
+//hostbuffer is go memory allocated by hostallocator
+type hostbuffer struct {
+	b []byte
+	p unsafe.Pointer
+}
+
+func (h *hostbuffer) Ptr() unsafe.Pointer   { return h.p }
+func (h *hostbuffer) DPtr() *unsafe.Pointer { return &h.p }
+func (h *hostbuffer) SIB() uint             { return uint(len(h.b)) }
+
+//hostallocator allocates go memory, which is freed by the garbage collector.
+type hostallocator struct{}
+
+func (hostallocator) Allocate(sib uint) (cutil.Mem, error) {
+	if sib == 0 {
+		return &hostbuffer{}, nil
+	}
+	b := make([]byte, sib)
+	return &hostbuffer{b: b, p: unsafe.Pointer(&b[0])}, nil
+}
+
+func (hostallocator) Free(m cutil.Mem) error {
+	return nil
+}
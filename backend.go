@@ -0,0 +1,219 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//Backend runs the operations that are called through a Handle.
+//
+//Every layer method (e.g. (*ConvolutionD)Forward()) hands its arguments to the Backend held by the Handle.
+//The default backend of a handle calls MIOpen (or the pure go kernels when built with the nomiopen or cpu tags).
+//A Backend can be wrapped to record, check, or inject faults into calls, or replaced by NewCPUBackend() to
+//run on host memory.  Methods are named after the MIOpen functions they stand in for.
+//
+//RNND and FusionPlanD always call MIOpen directly.
+type Backend interface {
+	SetTensor(t *TensorD, tmem cutil.Mem, alpha float64) error
+	ScaleTensor(t *TensorD, tmem cutil.Mem, alpha float64) error
+	//LogTensor sets t to ln(t).  MIOpen has no log, the rocm backend takes it on the host.
+	LogTensor(t *TensorD, tmem cutil.Mem) error
+	TransformTensor(alpha float64, xD *TensorD, x cutil.Mem, beta float64, yD *TensorD, y cutil.Mem) error
+	OpTensor(op OpTensorOp,
+		alpha float64, aD *TensorD, a cutil.Mem,
+		alpha2 float64, bD *TensorD, b cutil.Mem,
+		beta float64, cD *TensorD, c cutil.Mem) error
+
+	ConvolutionForwardGetWorkSpaceSize(c *ConvolutionD, wD, xD, yD *TensorD) (wspaceSIB uint, err error)
+	FindConvolutionForwardAlgorithm(c *ConvolutionD,
+		xD *TensorD, x cutil.Mem,
+		wD *TensorD, w cutil.Mem,
+		yD *TensorD, y cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint,
+		opts FindOptions) ([]ConvFwdAlgoPerf, error)
+	ConvolutionForward(c *ConvolutionD, alpha float64,
+		xD *TensorD, x cutil.Mem,
+		wD *TensorD, w cutil.Mem,
+		algo ConvFwdAlgorithm,
+		beta float64,
+		yD *TensorD, y cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint) error
+	ConvolutionForwardBias(c *ConvolutionD, alpha float64,
+		bD *TensorD, b cutil.Mem,
+		beta float64,
+		yD *TensorD, y cutil.Mem) error
+	ConvolutionBiasActivationForward(c *ConvolutionD, alpha1 float64,
+		xD *TensorD, x cutil.Mem,
+		wD *TensorD, w cutil.Mem,
+		algo ConvFwdAlgorithm,
+		wspace cutil.Mem, wspaceSIB uint,
+		alpha2 float64,
+		zD *TensorD, z cutil.Mem,
+		bD *TensorD, b cutil.Mem,
+		a *ActivationD,
+		yD *TensorD, y cutil.Mem) error
+	ConvolutionBackwardDataGetWorkSpaceSize(c *ConvolutionD, dyD, wD, dxD *TensorD) (wspaceSIB uint, err error)
+	FindConvolutionBackwardDataAlgorithm(c *ConvolutionD,
+		dyD *TensorD, dy cutil.Mem,
+		wD *TensorD, w cutil.Mem,
+		dxD *TensorD, dx cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint,
+		opts FindOptions) ([]ConvBwdDataAlgoPerf, error)
+	ConvolutionBackwardData(c *ConvolutionD, alpha float64,
+		dyD *TensorD, dy cutil.Mem,
+		wD *TensorD, w cutil.Mem,
+		algo ConvBwdDataAlgorithm,
+		beta float64,
+		dxD *TensorD, dx cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint) error
+	ConvolutionBackwardWeightsGetWorkSpaceSize(c *ConvolutionD, dyD, xD, dwD *TensorD) (wspaceSIB uint, err error)
+	FindConvolutionBackwardWeightsAlgorithm(c *ConvolutionD,
+		dyD *TensorD, dy cutil.Mem,
+		xD *TensorD, x cutil.Mem,
+		dwD *TensorD, dw cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint,
+		opts FindOptions) ([]ConvBwdWeightAlgoPerf, error)
+	ConvolutionBackwardWeights(c *ConvolutionD, alpha float64,
+		dyD *TensorD, dy cutil.Mem,
+		xD *TensorD, x cutil.Mem,
+		algo ConvBwdWeightsAlgorithm,
+		beta float64,
+		dwD *TensorD, dw cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint) error
+	ConvolutionBackwardBias(c *ConvolutionD, alpha float64,
+		dyD *TensorD, dy cutil.Mem,
+		beta float64,
+		dbD *TensorD, db cutil.Mem) error
+
+	ConvolutionForwardGetSolutionCount(c *ConvolutionD, wD, xD, yD *TensorD) (uint, error)
+	ConvolutionForwardGetSolution(c *ConvolutionD, wD, xD, yD *TensorD, max uint) ([]ConvSolution, error)
+	ConvolutionForwardGetSolutionWorkspaceSize(c *ConvolutionD, wD, xD, yD *TensorD, solutionID uint64) (uint, error)
+	ConvolutionForwardCompileSolution(c *ConvolutionD, wD, xD, yD *TensorD, solutionID uint64) error
+	ConvolutionForwardImmediate(c *ConvolutionD,
+		wD *TensorD, w cutil.Mem,
+		xD *TensorD, x cutil.Mem,
+		yD *TensorD, y cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint,
+		solutionID uint64) error
+
+	ConvolutionBackwardDataGetSolutionCount(c *ConvolutionD, dyD, wD, dxD *TensorD) (uint, error)
+	ConvolutionBackwardDataGetSolution(c *ConvolutionD, dyD, wD, dxD *TensorD, max uint) ([]ConvSolution, error)
+	ConvolutionBackwardDataGetSolutionWorkspaceSize(c *ConvolutionD, dyD, wD, dxD *TensorD, solutionID uint64) (uint, error)
+	ConvolutionBackwardDataCompileSolution(c *ConvolutionD, dyD, wD, dxD *TensorD, solutionID uint64) error
+	ConvolutionBackwardDataImmediate(c *ConvolutionD,
+		dyD *TensorD, dy cutil.Mem,
+		wD *TensorD, w cutil.Mem,
+		dxD *TensorD, dx cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint,
+		solutionID uint64) error
+
+	ConvolutionBackwardWeightsGetSolutionCount(c *ConvolutionD, dyD, xD, dwD *TensorD) (uint, error)
+	ConvolutionBackwardWeightsGetSolution(c *ConvolutionD, dyD, xD, dwD *TensorD, max uint) ([]ConvSolution, error)
+	ConvolutionBackwardWeightsGetSolutionWorkspaceSize(c *ConvolutionD, dyD, xD, dwD *TensorD, solutionID uint64) (uint, error)
+	ConvolutionBackwardWeightsCompileSolution(c *ConvolutionD, dyD, xD, dwD *TensorD, solutionID uint64) error
+	ConvolutionBackwardWeightsImmediate(c *ConvolutionD,
+		dyD *TensorD, dy cutil.Mem,
+		xD *TensorD, x cutil.Mem,
+		dwD *TensorD, dw cutil.Mem,
+		wspace cutil.Mem, wspaceSIB uint,
+		solutionID uint64) error
+
+	ActivationForward(a *ActivationD, alpha float64,
+		xD *TensorD, x cutil.Mem,
+		beta float64,
+		yD *TensorD, y cutil.Mem) error
+	ActivationBackward(a *ActivationD, alpha float64,
+		yD *TensorD, y cutil.Mem,
+		dyD *TensorD, dy cutil.Mem,
+		xD *TensorD, x cutil.Mem,
+		beta float64,
+		dxD *TensorD, dx cutil.Mem) error
+
+	PoolingForward(p *PoolingD, alpha float64,
+		xD *TensorD, x cutil.Mem,
+		beta float64,
+		yD *TensorD, y cutil.Mem,
+		dobackwards bool, wspace cutil.Mem, wspaceSIB uint) error
+	PoolingBackward(p *PoolingD, alpha float64,
+		yD *TensorD, y cutil.Mem,
+		dyD *TensorD, dy cutil.Mem,
+		xD *TensorD, x cutil.Mem,
+		beta float64,
+		dxD *TensorD, dx cutil.Mem,
+		wspace cutil.Mem) error
+
+	SoftmaxForward(alpha float64,
+		xD *TensorD, x cutil.Mem,
+		beta float64,
+		yD *TensorD, y cutil.Mem) error
+	SoftmaxBackward(alpha float64,
+		yD *TensorD, y cutil.Mem,
+		dyD *TensorD, dy cutil.Mem,
+		beta float64,
+		dxD *TensorD, dx cutil.Mem) error
+
+	LRNForward(l *LRND, alpha float64,
+		xD *TensorD, x cutil.Mem,
+		beta float64,
+		yD *TensorD, y cutil.Mem,
+		doBackwards bool, wspace cutil.Mem) error
+	LRNBackward(l *LRND, alpha float64,
+		yD *TensorD, y cutil.Mem,
+		dyD *TensorD, dy cutil.Mem,
+		xD *TensorD, x cutil.Mem,
+		beta float64,
+		dxD *TensorD, dx cutil.Mem,
+		wspace cutil.Mem) error
+
+	BatchNormalizationForwardInference(b *BatchNormD, alpha, beta float64,
+		xD *TensorD, x cutil.Mem,
+		yD *TensorD, y cutil.Mem,
+		scalbiasmeanvarD *TensorD,
+		scale, bias cutil.Mem,
+		mean, variance cutil.Mem,
+		epsilon float64) error
+	BatchNormalizationForwardTraining(b *BatchNormD, alpha, beta float64,
+		xD *TensorD, x cutil.Mem,
+		yD *TensorD, y cutil.Mem,
+		scalbiasmeanvarD *TensorD,
+		scale, bias cutil.Mem,
+		avgfactor float64,
+		mean, variance cutil.Mem,
+		epsilon float64,
+		saveMean, saveInvariance cutil.Mem) error
+	BatchNormalizationBackward(b *BatchNormD, alphaDataDiff, betaDataDiff, alphaParamDiff, betaParamDiff float64,
+		xD *TensorD, x cutil.Mem,
+		dyD *TensorD, dy cutil.Mem,
+		dxD *TensorD, dx cutil.Mem,
+		scalebiasdiffD *TensorD,
+		scale, scalediff, biasdiff cutil.Mem,
+		epsilon float64,
+		savedMean, savedInvVariance cutil.Mem) error
+
+	GetReductionIndicesSize(rt *ReduceTensorD, aD, cD *TensorD) (indicesSIB uint, err error)
+	GetReductionWorkspaceSize(rt *ReduceTensorD, aD, cD *TensorD) (wspaceSIB uint, err error)
+	ReduceTensor(rt *ReduceTensorD,
+		indices cutil.Mem, indicesSIB uint,
+		wspace cutil.Mem, wspaceSIB uint,
+		alpha float64, aD *TensorD, a cutil.Mem,
+		beta float64, cD *TensorD, c cutil.Mem) error
+}
+
+//profiler is implemented by backends that time their own operations.  Handle's profiling methods use it
+//instead of MIOpen when the backend has one.
+type profiler interface {
+	EnableProfiling(enable bool) error
+	GetKernelTime() (time float32, err error)
+}
+
+//Backend returns the backend that h sends its operations to.
+//
+//It can be used to wrap the default backend and pass the wrapper to SetBackend.
+func (h *Handle) Backend() Backend {
+	return h.b
+}
+
+//SetBackend sets the backend that h will send its operations to.  If b is nil the default backend is restored.
+func (h *Handle) SetBackend(b Backend) {
+	if b == nil {
+		b = h.defaultbackend()
+	}
+	h.b = b
+}
@@ -0,0 +1,148 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//ForwardInference -  Execute forward inference layer for batch normalization
+//
+//Batch normalization pass for forward inference pass.
+//Takes in batch normalization mode bn_mode and input tensor x, output tensor y, bnBias and bnScale
+//with their descriptor.
+//
+//If either mean, variance are nil pointers then the values for the mean and
+//variance will not be used.
+//
+//	handle				MIOpen handle (input)
+//	alpha				Floating point scaling factor, allocated on the host (input)
+//	beta				Floating point shift factor, allocated on the host (input)
+//	sD				Tensor descriptor for data input tensor x (input)
+//	x				Data tensor x (input)
+//	yD				Tensor descriptor for output data tensor y (input)
+//	y				Data tensor y (output)
+//	scalbiasmeanvarD				Tensor descriptor for BN scaling, shifting, saved variance and mean (input)
+//	scale				Batch norm scaling, gamma, tensor (input)
+//	bias				Batch norm bias, beta, tensor (input)
+//	mean				Running average saved during forward training (input)
+//	variance				Running variance saved during forward training (input)
+//	epsilon				Value to stabilize inverse variance calculation (input)
+func (b *BatchNormD) ForwardInference(h *Handle, alpha, beta float64,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	scalbiasmeanvarD *TensorD,
+	scale, bias cutil.Mem, //returned values
+	mean, variance cutil.Mem, //returned values
+	epsilon float64,
+) error {
+	k := check{op: "(*BatchNormD)ForwardInference()"}
+	k.batchnorm(b, k.tensor("xD", xD, x), k.tensor("yD", yD, y), k.tensor("scalbiasmeanvarD", scalbiasmeanvarD, scale))
+	k.mem("bias", bias)
+	err := k.err
+	if err == nil {
+		err = h.b.BatchNormalizationForwardInference(b, alpha, beta, xD, x, yD, y, scalbiasmeanvarD, scale, bias, mean, variance, epsilon)
+	}
+	return withdesc(err, "b", b, "xD", xD, "yD", yD, "scalbiasmeanvarD", scalbiasmeanvarD)
+}
+
+//ForwardTraining - Execute forward training layer for batch normalization
+//
+//Batch normalization pass for forward training pass.
+//Takes in batch normalization mode bn_mode and input tensor x, output tensor y, bnBias and bnScale
+//with their descriptor.
+//
+//If either saveMean, or saveInvariance are nil then the values for the mean
+//and inverse variance will not be used.
+//
+//Likewise, if either mean, or variance are nil then the values
+//for the running mean and variance will not be saved.
+//
+//Running averages and variances are scaled using an exponential averaging factor:
+//
+//	M.old =M.new*factor + M.old*(1-factor)
+//	where factor=1/(1+iteration)
+//
+//Params:
+//	h			MIOpen handle (input)
+//	alpha			Floating point scaling factor, allocated on the host (input)
+//	beta			Floating point shift factor, allocated on the host (input)
+//	xD			Tensor descriptor for data input tensor x (input)
+//	x			Data tensor x (input)
+//	yD			Tensor descriptor for output data tensor y (input)
+//	y			Data tensor y (output)
+//	scalbiasmeanvarD	Tensor descriptor for BN scaling, shifting, saved variance and mean (input)
+//	scale			Batch norm scaling, gamma, tensor (input)
+//	bias			Batch norm bias, beta, tensor (input)
+//	avgfactor		Exponential averaging factor (input)
+//	mean			Running average saved for inference (output)
+//	variance		Running variance saved for inference (output)
+//	epsilon			Value to stablize inverse variance calculation (input)
+//	saveMean		Saved mini-batch mean for backwards pass (output)
+//	saveInvariance		Saved mini-batch inverse variance for backwards pass (output)
+func (b *BatchNormD) ForwardTraining(h *Handle, alpha, beta float64,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	scalbiasmeanvarD *TensorD,
+	scale, bias cutil.Mem, //returned values
+	avgfactor float64,
+	mean, variance cutil.Mem, //returned values
+	epsilon float64,
+	saveMean, saveInvariance cutil.Mem, //returned vallues
+) error {
+	k := check{op: "(*BatchNormD)ForwardTraining()"}
+	k.batchnorm(b, k.tensor("xD", xD, x), k.tensor("yD", yD, y), k.tensor("scalbiasmeanvarD", scalbiasmeanvarD, scale))
+	k.mem("bias", bias)
+	err := k.err
+	if err == nil {
+		err = h.b.BatchNormalizationForwardTraining(b, alpha, beta, xD, x, yD, y, scalbiasmeanvarD, scale, bias, avgfactor, mean, variance, epsilon, saveMean, saveInvariance)
+	}
+	return withdesc(err, "b", b, "xD", xD, "yD", yD, "scalbiasmeanvarD", scalbiasmeanvarD)
+}
+
+//Backward - Execute backwards propagation layer for batch normalization
+//
+//Batch normalization pass for backwards propagation training pass.
+//The method for backwards propagation batch normalization.
+//
+//Takes in batch normalization mode bn_mode and input tensor data x, input activation tensor dy,
+//output tensor dx, the learned tensors resultBNBiasDiff and resultBNScaleDiff with their
+//descriptor.
+//
+//If BOTH savedMean, and savedVariance are not null pointers then the method will use the saved
+//mean and variance calculated by the forward training phase.
+//
+//	h				MIOpen handle (input)
+//	alphaDataDiff			Floating point scaling factor, allocated on the host (input)
+//	betaDataDiff			Floating point shift factor, allocated on the host (input)
+//	alphaParamDiff			Floating point scaling factor, allocated on the host (input)
+//	betaParamDiff			Floating point shift factor, allocated on the host (input)
+//	xD				Tensor descriptor for data input tensor x (input)
+//	x				Data tensor x (input)
+//	dyD				Tensor descriptor for output data tensor y (input)
+//	dy				Data tensor y (input)
+//	dxD				Tensor descriptor for output data tensor dx (input)
+//	dx				Data delta tensor dx (output)
+//	scalebiasdiffD 			Tensor descriptor for BN scaling, shifting, saved variance and mean (input)
+//	bnScale				Batch norm scaling, gamma, tensor (input)
+//	scalediff			Tensor for dscale (output)
+//	biasdiff			Tensor for dbias (output)
+//	epsilon				Value to stabilize inverse variance calculation (input)
+//	savedMean			Saved mini-batch mean for backwards pass (input)
+//	savedInvVariance		Saved mini-bathc inverse variance for backwards pass (input)
+func (b *BatchNormD) Backward(h *Handle, alphaDataDiff, betaDataDiff, alphaParamDiff, betaParamDiff float64,
+	xD *TensorD, x cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	scalebiasdiffD *TensorD,
+	scale, scalediff, biasdiff cutil.Mem,
+	epsilon float64,
+	savedMean, savedInvVariance cutil.Mem) error {
+	k := check{op: "(*BatchNormD)Backward()"}
+	xt, dxt := k.tensor("xD", xD, x), k.tensor("dxD", dxD, dx)
+	k.batchnorm(b, xt, k.tensor("dyD", dyD, dy), k.tensor("scalebiasdiffD", scalebiasdiffD, scale))
+	k.sameshape(xt, dxt)
+	k.mem("scalediff", scalediff)
+	k.mem("biasdiff", biasdiff)
+	err := k.err
+	if err == nil {
+		err = h.b.BatchNormalizationBackward(b, alphaDataDiff, betaDataDiff, alphaParamDiff, betaParamDiff, xD, x, dyD, dy, dxD, dx, scalebiasdiffD, scale, scalediff, biasdiff, epsilon, savedMean, savedInvVariance)
+	}
+	return withdesc(err, "b", b, "xD", xD, "dyD", dyD, "dxD", dxD, "scalebiasdiffD", scalebiasdiffD)
+}
@@ -1,3 +1,6 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 /*
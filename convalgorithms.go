@@ -0,0 +1,224 @@
+package miopen
+
+import (
+	"fmt"
+	"strings"
+)
+
+//The algorithm flags print, and marshal to text and JSON, as the names of their setters so they can be
+//logged and read from config files.
+//
+//	var algo miopen.ConvFwdAlgorithm
+//	algo.UnmarshalText([]byte("WinoGrad")) //same as algo.WinoGrad()
+
+//algoname is one value of an algorithm flag type and the first MIOpen version whose headers have it
+type algoname struct {
+	name  string
+	value int32
+	since SemVer
+}
+
+//algoflag names the values of one algorithm flag type
+type algoflag struct {
+	typ    string
+	names  []string
+	values []int32
+}
+
+//newalgoflag leaves out the values the headers the package was built against don't have
+func newalgoflag(typ string, all ...algoname) algoflag {
+	a := algoflag{typ: typ}
+	h := headerversion()
+	for _, n := range all {
+		if h.Less(n.since) {
+			continue
+		}
+		a.names = append(a.names, n.name)
+		a.values = append(a.values, n.value)
+	}
+	return a
+}
+
+func (a algoflag) name(v int32) string {
+	for i := range a.values {
+		if a.values[i] == v {
+			return a.names[i]
+		}
+	}
+	return fmt.Sprintf("%s(%d)", a.typ, v)
+}
+
+func (a algoflag) marshal(v int32) ([]byte, error) {
+	for i := range a.values {
+		if a.values[i] == v {
+			return []byte(a.names[i]), nil
+		}
+	}
+	return nil, statusBadParm.error(fmt.Sprintf("(%s)MarshalText(): %d is not a %s", a.typ, v, a.typ))
+}
+
+//unmarshal matches text against the names ignoring case
+func (a algoflag) unmarshal(text []byte) (int32, error) {
+	for i := range a.names {
+		if strings.EqualFold(a.names[i], string(text)) {
+			return a.values[i], nil
+		}
+	}
+	return 0, statusBadParm.error(fmt.Sprintf("(*%s)UnmarshalText(): %q is not one of %v", a.typ, text, a.names))
+}
+
+func (c ConvFwdAlgorithm) flag() algoflag {
+	var (
+		f  ConvFwdAlgorithm
+		ft Feature
+	)
+	return newalgoflag("ConvFwdAlgorithm",
+		algoname{"GEMM", int32(f.GEMM()), SemVer{}},
+		algoname{"Direct", int32(f.Direct()), SemVer{}},
+		algoname{"FFT", int32(f.FFT()), SemVer{}},
+		algoname{"WinoGrad", int32(f.WinoGrad()), SemVer{}},
+		algoname{"ImplicitGEMM", int32(f.ImplicitGEMM()), ft.FwdImplicitGEMM().Since()},
+	)
+}
+
+//All returns every ConvFwdAlgorithm flag the MIOpen headers the package was built against have
+func (c ConvFwdAlgorithm) All() []ConvFwdAlgorithm {
+	values := c.flag().values
+	all := make([]ConvFwdAlgorithm, len(values))
+	for i := range values {
+		all[i] = ConvFwdAlgorithm(values[i])
+	}
+	return all
+}
+
+//String returns the name of the flag, e.g. "WinoGrad"
+func (c ConvFwdAlgorithm) String() string { return c.flag().name(int32(c)) }
+
+//MarshalText returns the name of the flag.  It returns an error if c isn't one of All().
+func (c ConvFwdAlgorithm) MarshalText() ([]byte, error) { return c.flag().marshal(int32(c)) }
+
+//UnmarshalText sets c to the flag named by text.  Case is ignored.
+func (c *ConvFwdAlgorithm) UnmarshalText(text []byte) error {
+	v, err := c.flag().unmarshal(text)
+	if err == nil {
+		*c = ConvFwdAlgorithm(v)
+	}
+	return err
+}
+
+func (c ConvBwdDataAlgorithm) flag() algoflag {
+	var (
+		f  ConvBwdDataAlgorithm
+		ft Feature
+	)
+	return newalgoflag("ConvBwdDataAlgorithm",
+		algoname{"GEMM", int32(f.GEMM()), SemVer{}},
+		algoname{"Direct", int32(f.Direct()), SemVer{}},
+		algoname{"FFT", int32(f.FFT()), SemVer{}},
+		algoname{"WinoGrad", int32(f.WinoGrad()), SemVer{}},
+		algoname{"TransposeGEMM", int32(f.TransposeGEMM()), ft.TransposeGEMM().Since()},
+		algoname{"ImplicitGEMM", int32(f.ImplicitGEMM()), ft.BwdImplicitGEMM().Since()},
+	)
+}
+
+//All returns every ConvBwdDataAlgorithm flag the MIOpen headers the package was built against have
+func (c ConvBwdDataAlgorithm) All() []ConvBwdDataAlgorithm {
+	values := c.flag().values
+	all := make([]ConvBwdDataAlgorithm, len(values))
+	for i := range values {
+		all[i] = ConvBwdDataAlgorithm(values[i])
+	}
+	return all
+}
+
+//String returns the name of the flag, e.g. "WinoGrad"
+func (c ConvBwdDataAlgorithm) String() string { return c.flag().name(int32(c)) }
+
+//MarshalText returns the name of the flag.  It returns an error if c isn't one of All().
+func (c ConvBwdDataAlgorithm) MarshalText() ([]byte, error) { return c.flag().marshal(int32(c)) }
+
+//UnmarshalText sets c to the flag named by text.  Case is ignored.
+func (c *ConvBwdDataAlgorithm) UnmarshalText(text []byte) error {
+	v, err := c.flag().unmarshal(text)
+	if err == nil {
+		*c = ConvBwdDataAlgorithm(v)
+	}
+	return err
+}
+
+func (c ConvBwdWeightsAlgorithm) flag() algoflag {
+	var (
+		f  ConvBwdWeightsAlgorithm
+		ft Feature
+	)
+	return newalgoflag("ConvBwdWeightsAlgorithm",
+		algoname{"GEMM", int32(f.GEMM()), SemVer{}},
+		algoname{"Direct", int32(f.Direct()), SemVer{}},
+		algoname{"WinoGrad", int32(f.WinoGrad()), SemVer{}},
+		algoname{"ImplicitGEMM", int32(f.ImplicitGEMM()), ft.BwdImplicitGEMM().Since()},
+	)
+}
+
+//All returns every ConvBwdWeightsAlgorithm flag the MIOpen headers the package was built against have
+func (c ConvBwdWeightsAlgorithm) All() []ConvBwdWeightsAlgorithm {
+	values := c.flag().values
+	all := make([]ConvBwdWeightsAlgorithm, len(values))
+	for i := range values {
+		all[i] = ConvBwdWeightsAlgorithm(values[i])
+	}
+	return all
+}
+
+//String returns the name of the flag, e.g. "WinoGrad"
+func (c ConvBwdWeightsAlgorithm) String() string { return c.flag().name(int32(c)) }
+
+//MarshalText returns the name of the flag.  It returns an error if c isn't one of All().
+func (c ConvBwdWeightsAlgorithm) MarshalText() ([]byte, error) { return c.flag().marshal(int32(c)) }
+
+//UnmarshalText sets c to the flag named by text.  Case is ignored.
+func (c *ConvBwdWeightsAlgorithm) UnmarshalText(text []byte) error {
+	v, err := c.flag().unmarshal(text)
+	if err == nil {
+		*c = ConvBwdWeightsAlgorithm(v)
+	}
+	return err
+}
+
+func (c ConvAlgorithm) flag() algoflag {
+	var (
+		f  ConvAlgorithm
+		ft Feature
+	)
+	return newalgoflag("ConvAlgorithm",
+		algoname{"GEMM", int32(f.GEMM()), ft.ImmediateMode().Since()},
+		algoname{"Direct", int32(f.Direct()), ft.ImmediateMode().Since()},
+		algoname{"FFT", int32(f.FFT()), ft.ImmediateMode().Since()},
+		algoname{"WinoGrad", int32(f.WinoGrad()), ft.ImmediateMode().Since()},
+		algoname{"ImplicitGEMM", int32(f.ImplicitGEMM()), ft.ImmediateMode().Since()},
+	)
+}
+
+//All returns every ConvAlgorithm flag the MIOpen headers the package was built against have
+func (c ConvAlgorithm) All() []ConvAlgorithm {
+	values := c.flag().values
+	all := make([]ConvAlgorithm, len(values))
+	for i := range values {
+		all[i] = ConvAlgorithm(values[i])
+	}
+	return all
+}
+
+//String returns the name of the flag, e.g. "ImplicitGEMM"
+func (c ConvAlgorithm) String() string { return c.flag().name(int32(c)) }
+
+//MarshalText returns the name of the flag.  It returns an error if c isn't one of All().
+func (c ConvAlgorithm) MarshalText() ([]byte, error) { return c.flag().marshal(int32(c)) }
+
+//UnmarshalText sets c to the flag named by text.  Case is ignored.
+func (c *ConvAlgorithm) UnmarshalText(text []byte) error {
+	v, err := c.flag().unmarshal(text)
+	if err == nil {
+		*c = ConvAlgorithm(v)
+	}
+	return err
+}
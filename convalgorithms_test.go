@@ -0,0 +1,46 @@
+package miopen_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestAlgorithmNames(t *testing.T) {
+	var bw miopen.ConvBwdWeightsAlgorithm
+	if bw.GEMM() == bw.Direct() {
+		t.Error("ConvBwdWeightsAlgorithm GEMM and Direct are the same flag")
+	}
+	var fwd miopen.ConvFwdAlgorithm
+	for _, a := range fwd.All() {
+		var b miopen.ConvFwdAlgorithm
+		if err := b.UnmarshalText([]byte(a.String())); err != nil || b != a {
+			t.Error("round trip of", a, "gave", b, err)
+		}
+	}
+	p := miopen.ConvPolicy{PreferFwd: []miopen.ConvFwdAlgorithm{fwd.WinoGrad()}, PreferBwdWeights: []miopen.ConvBwdWeightsAlgorithm{bw.GEMM()}}
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var q miopen.ConvPolicy
+	if err = json.Unmarshal(b, &q); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"PreferFwd":["WinoGrad"]`) || len(q.PreferBwdWeights) != 1 || q.PreferBwdWeights[0] != bw.GEMM() {
+		t.Error("ConvPolicy JSON", string(b), "read back as", q)
+	}
+	if err = json.Unmarshal([]byte(`{"PreferBwdData":["winograd","Sideways"]}`), &q); !errors.Is(err, miopen.ErrBadParm) {
+		t.Error("expected an unknown algorithm to fail, got", err)
+	}
+	var bd miopen.ConvBwdDataAlgorithm
+	if all := bd.All(); all[len(all)-1] != bd.ImplicitGEMM() || bd.TransposeGEMM().String() != "TransposeGEMM" {
+		t.Error("ConvBwdDataAlgorithm.All() is", all)
+	}
+	if s := miopen.ConvFwdAlgorithm(42).String(); s != "ConvFwdAlgorithm(42)" {
+		t.Error("String of an unknown flag is", s)
+	}
+}
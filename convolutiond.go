@@ -0,0 +1,25 @@
+package miopen
+
+//Set2D sets c to a 2D convolution.  The arrays are {height, width}.
+//
+//	var mode miopen.ConvolutionMode
+//	err := c.Set2D([2]int32{1, 1}, [2]int32{1, 1}, [2]int32{1, 1}, mode.Convolution())
+func (c *ConvolutionD) Set2D(pad, stride, dilation [2]int32, mode ConvolutionMode) error {
+	return c.Set(pad[:], stride[:], dilation[:], mode)
+}
+
+//Set3D sets c to a 3D convolution.  The arrays are {depth, height, width}.
+func (c *ConvolutionD) Set3D(pad, stride, dilation [3]int32, mode ConvolutionMode) error {
+	return c.Set(pad[:], stride[:], dilation[:], mode)
+}
+
+//GetTransposeOutputPadding returns the output padding of a transpose convolution, one value per spatial dim.
+//It is all zeros if SetTransposeOutputPadding wasn't called.
+//
+//MIOpen has no call to read the output padding back, so it is the value last set through c.
+func (c *ConvolutionD) GetTransposeOutputPadding() ([]int32, error) {
+	if _, _, _, _, err := c.Get(); err != nil {
+		return nil, err
+	}
+	return append([]int32(nil), c.outputpadding()...), nil
+}
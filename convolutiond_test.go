@@ -0,0 +1,43 @@
+package miopen_test
+
+import (
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestConvolutionSet2D3D(t *testing.T) {
+	var mode miopen.ConvolutionMode
+	c, err := miopen.CreateConvolutionDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Set3D([3]int32{0, 1, 1}, [3]int32{1, 2, 2}, [3]int32{1, 1, 1}, mode.Transpose()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetGroupCount(2); err != nil {
+		t.Fatal(err)
+	}
+	pad, stride, _, m, err := c.Get()
+	if err != nil || !equal(pad, []int32{0, 1, 1}) || !equal(stride, []int32{1, 2, 2}) || m != mode.Transpose() {
+		t.Fatal("Get", pad, stride, m, err)
+	}
+	if g, err := c.GetGroupCount(); err != nil || g != 2 {
+		t.Error("GetGroupCount", g, err)
+	}
+	if adj, err := c.GetTransposeOutputPadding(); err != nil || !equal(adj, []int32{0, 0, 0}) {
+		t.Error("GetTransposeOutputPadding", adj, err)
+	}
+	if err = c.SetTransposeOutputPadding([]int32{0, 1, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if adj, err := c.GetTransposeOutputPadding(); err != nil || !equal(adj, []int32{0, 1, 1}) {
+		t.Error("GetTransposeOutputPadding", adj, err)
+	}
+	if err = c.Set2D([2]int32{1, 1}, [2]int32{1, 1}, [2]int32{1, 1}, mode.Convolution()); err != nil {
+		t.Fatal(err)
+	}
+	if pad, _, _, _, err = c.Get(); err != nil || len(pad) != 2 {
+		t.Error("Get after Set2D", pad, err)
+	}
+}
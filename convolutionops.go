@@ -0,0 +1,521 @@
+package miopen
+
+import (
+	"errors"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//Forward - Execute a forward convolution layer
+//
+//Runs the forward convolution layer based on the selected algorithm. The function
+//(*ConvolutionD) FindForwardAlgorithm() must have been executed previously to
+//determine the required memory needed for the workspace and the best convolutional algorithm.
+//
+//If using Group/Depthwise convolution mode, call (*ConvolutionD)SetGroupCount() before running
+//this.
+//
+//	h				MIOpen handle (input)
+//	alpha			Floating point scaling factor, allocated on the host (input)
+//	xD				Tensor descriptor for data input tensor x (input)
+//	x				Data tensor x (input)
+//	wD				Tensor descriptor for weight tensor w (input)
+//	w				Weights tensor w (inputs)
+//	algo			Algorithm selected (inputs)
+//	beta			Floating point shift factor, allocated on the host (input)
+//	yD				Tensor descriptor for output data tensor y (input)
+//	y				Data tensor y (output)
+//	wspace			Pointer to workspace required (input)
+//	wspaceSIB		Size in bytes of the memory determined by the find step (input)
+func (c *ConvolutionD) Forward(
+	h *Handle,
+	alpha float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo *ConvFwdAlgorithm,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+) error {
+	k := check{op: "(*ConvolutionD)Forward()"}
+	k.conv(c, k.tensor("xD", xD, x), k.tensor("wD", wD, w), k.tensor("yD", yD, y))
+	k.wspace(wspace, wspaceSIB)
+	if algo == nil {
+		k.fail("algo is nil")
+	} else {
+		k.algorithm(int32(*algo), false)
+	}
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionForward(c, alpha, xD, x, wD, w, *algo, beta, yD, y, wspace, wspaceSIB)
+	}
+	return withdesc(err, "c", c, "xD", xD, "wD", wD, "yD", yD)
+}
+
+//ForwardBias - Calculate element-wise scale and shift of a tensor via a bias tensor
+//
+//This function applies an element-wise bias to a data tensor from an input bias tensor.
+//	handle         MIOpen handle (input)
+//	alpha          Floating point scaling factor, allocated on the host (input)
+//	bDesc          Tensor descriptor for bias tensor b (input)
+//	b              Bias tensor b (input)
+//	beta           Floating point shift factor, allocated on the host (input)
+//	yDesc          Tensor descriptor for data tensor y (input)
+//	y              Data tensor y (input and output)
+func (c *ConvolutionD) ForwardBias(h *Handle,
+	alpha float64,
+	bD *TensorD, b cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+) error {
+	k := check{op: "(*ConvolutionD)ForwardBias()"}
+	bt, yt := k.tensor("bD", bD, b), k.tensor("yD", yD, y)
+	k.samedtype(yt, bt)
+	k.bias(bt, yt)
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionForwardBias(c, alpha, bD, b, beta, yD, y)
+	}
+	return withdesc(err, "c", c, "bD", bD, "yD", yD)
+}
+
+//ForwardBiasActivation - Execute a forward convolution followed by a bias and an activation
+//
+//	y = activation(alpha1 * conv(x, w) + alpha2 * z + b)
+//
+//It calls miopenConvolutionBiasActivationForward when the MIOpen the package is built against and linked
+//to has it, and when MIOpen supports the configuration.  Otherwise it falls back to Forward, ForwardBias
+//and (*ActivationD)Forward, with a TransformTensor first if z is passed.  Both give the same y.
+//
+//	h				MIOpen handle (input)
+//	alpha1			Floating point scaling factor of the convolution, allocated on the host (input)
+//	xD				Tensor descriptor for data input tensor x (input)
+//	x				Data tensor x (input)
+//	wD				Tensor descriptor for weight tensor w (input)
+//	w				Weights tensor w (input)
+//	algo			Algorithm selected (input)
+//	wspace			Pointer to workspace required (input)
+//	wspaceSIB		Size in bytes of the workspace of algo (input)
+//	alpha2			Floating point scaling factor of z, allocated on the host (input)
+//	zD				Tensor descriptor for the residual tensor z shaped like y. nil to skip it (input)
+//	z				Residual tensor z. It can be y (input)
+//	bD				Tensor descriptor for bias tensor b, 1 x C x 1... (input)
+//	b				Bias tensor b (input)
+//	a				Activation descriptor (input)
+//	yD				Tensor descriptor for output data tensor y (input)
+//	y				Data tensor y (output)
+func (c *ConvolutionD) ForwardBiasActivation(h *Handle,
+	alpha1 float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvFwdAlgorithm,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha2 float64,
+	zD *TensorD, z cutil.Mem,
+	bD *TensorD, b cutil.Mem,
+	a *ActivationD,
+	yD *TensorD, y cutil.Mem,
+) error {
+	k := check{op: "(*ConvolutionD)ForwardBiasActivation()"}
+	yt := k.tensor("yD", yD, y)
+	k.conv(c, k.tensor("xD", xD, x), k.tensor("wD", wD, w), yt)
+	bt := k.tensor("bD", bD, b)
+	k.samedtype(yt, bt)
+	k.bias(bt, yt)
+	if zD != nil {
+		zt := k.tensor("zD", zD, z)
+		k.sameshape(yt, zt)
+		k.samedtype(yt, zt)
+	}
+	if a == nil {
+		k.fail("activation descriptor is nil")
+	}
+	k.wspace(wspace, wspaceSIB)
+	k.algorithm(int32(algo), false)
+	err := k.err
+	if err == nil {
+		var f Feature
+		err = Supported(f.ConvBiasActivation())
+		if err == nil {
+			err = h.b.ConvolutionBiasActivationForward(c, alpha1, xD, x, wD, w, algo, wspace, wspaceSIB, alpha2, zD, z, bD, b, a, yD, y)
+		}
+		if errors.Is(err, ErrUnsupportedOp) || errors.Is(err, ErrNotImplemented) {
+			err = biasactivation(h.b, c, alpha1, xD, x, wD, w, algo, wspace, wspaceSIB, alpha2, zD, z, bD, b, a, yD, y)
+		}
+	}
+	return withdesc(err, "c", c, "xD", xD, "wD", wD, "zD", zD, "bD", bD, "a", a, "yD", yD)
+}
+
+//biasactivation is ConvolutionBiasActivationForward made out of the calls it fuses.  It is the fallback of
+//ForwardBiasActivation and the cpu backend's version.
+func biasactivation(be Backend, c *ConvolutionD, alpha1 float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvFwdAlgorithm,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha2 float64,
+	zD *TensorD, z cutil.Mem,
+	bD *TensorD, b cutil.Mem,
+	a *ActivationD,
+	yD *TensorD, y cutil.Mem) error {
+	beta := 0.0
+	if zD != nil {
+		//y = alpha2*z first so z can be y
+		if err := be.TransformTensor(alpha2, zD, z, 0, yD, y); err != nil {
+			return err
+		}
+		beta = 1
+	}
+	if err := be.ConvolutionForward(c, alpha1, xD, x, wD, w, algo, beta, yD, y, wspace, wspaceSIB); err != nil {
+		return err
+	}
+	if err := be.ConvolutionForwardBias(c, 1, bD, b, 1, yD, y); err != nil {
+		return err
+	}
+	return be.ActivationForward(a, 1, yD, y, 0, yD, y)
+}
+
+//BackwardData -Execute a backward data convolution layer
+// Runs the backward data convolution layer based on the selected algorithm. The function
+// (*ConvolutionD)GetBwdDataWorkspaceSize() must have been executed previously to
+// determine the required memory needed for the workspace and the (*ConvolutionD) FindBwdDataAlgo() for the best convolutional algorithm.
+//
+//If using Group/Depthwise convolution mode, call  (*ConvolutionD)SetGroupCount() before running this.
+//
+//If h is in deterministic mode an algorithm that isn't deterministic returns ErrBadParm.  See (*Handle)SetDeterministic().
+//
+//	h		MIOpen handle (input)
+//	alpha		Floating point scaling factor, allocated on the host (input)
+//	dyD		Tensor descriptor for data input tensor dy (input)
+//	dy		Data delta tensor dy (input)
+//	wD		Tensor descriptor for weight tensor w (input)
+//	w		Weights tensor w (input)
+//	algo		Algorithm selected (input)
+//	beta		Floating point shift factor, allocated on the host (input)
+//	dxD		Tensor descriptor for output data tensor dx (input)
+//	dx		Data delta tensor dx (output)
+//	wspace		Pointer to workspace required for the search (input)
+//	wspaceSIB		Size in bytes of the memory needed for find (input)
+func (c *ConvolutionD) BackwardData(h *Handle,
+	alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvBwdDataAlgorithm,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	k := check{op: "(*ConvolutionD)BackwardData()"}
+	k.conv(c, k.tensor("dxD", dxD, dx), k.tensor("wD", wD, w), k.tensor("dyD", dyD, dy))
+	k.wspace(wspace, wspaceSIB)
+	k.algorithm(int32(algo), true)
+	k.deterministic(h, c, int32(algo))
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionBackwardData(c, alpha, dyD, dy, wD, w, algo, beta, dxD, dx, wspace, wspaceSIB)
+	}
+	return withdesc(err, "c", c, "dyD", dyD, "wD", wD, "dxD", dxD)
+}
+
+//BackwardWeights - Execute a backward weights convolution layer
+//
+//Runs the backward weights convolution layer based on the selected algorithm. The function
+//(*ConvolutionD)GetBwdWeightsWorkspaceSize() must have been executed previously to determine the required memory needed
+//for the workspace and the (*ConvolutionD) FindBwdWeightsAlgorithm() for the best convolutional algorithm.
+//
+//If using Group/Depthwise convolution mode, call (*ConvolutionD)SetGroupCount() before running this.
+//
+//If h is in deterministic mode an algorithm that isn't deterministic returns ErrBadParm.  See (*Handle)SetDeterministic().
+//
+//handle		MIOpen handle (input)
+//alpha		Floating point scaling factor, allocated on the host (input)
+//dyD		Tensor descriptor for data tensor dy (input)
+//dy		Data delta tensor dy (input)
+//xD		Tensor descriptor for data tensor x (input)
+//x		Data tensor x (input)
+//algo		Algorithm selected (input)
+//beta		Floating point shift factor, allocated on the host (input)
+//dwD		Tensor descriptor for weight tensor dw (input)
+//dw		Weights delta tensor dw (output)
+//wspace		Pointer to workspace required for the search (input)
+//wspaceSIB		Size in bytes of the memory needed for find (input)
+//
+func (c *ConvolutionD) BackwardWeights(h *Handle,
+	alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	algo ConvBwdWeightsAlgorithm,
+	beta float64,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	k := check{op: "(*ConvolutionD)BackwardWeights()"}
+	k.conv(c, k.tensor("xD", xD, x), k.tensor("dwD", dwD, dw), k.tensor("dyD", dyD, dy))
+	k.wspace(wspace, wspaceSIB)
+	k.algorithm(int32(algo), true)
+	k.deterministic(h, c, int32(algo))
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionBackwardWeights(c, alpha, dyD, dy, xD, x, algo, beta, dwD, dw, wspace, wspaceSIB)
+	}
+	return withdesc(err, "c", c, "dyD", dyD, "xD", xD, "dwD", dwD)
+}
+
+//BackwardBias - Calculates the gradient with respect to the bias.
+//
+//Compute the convolution backwards gradient with respect to the bias tensor.
+//
+//	h		MIOpen handle (input)
+//	alpha		Floating point scaling factor, allocated on the host (input)
+//	dyD		Tensor descriptor for data input tensor dy (input)
+//	dy		Data delta tensor dy (input)
+//	beta		point shift factor, allocated on the host (input)
+//	dbD		Tensor descriptor for input bias tensor db (input)
+//	db		Bias delta tensor db (output)
+func (c *ConvolutionD) BackwardBias(
+	h *Handle,
+	alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	beta float64,
+	dbD *TensorD, db cutil.Mem) error {
+	k := check{op: "(*ConvolutionD)BackwardBias()"}
+	dyt, dbt := k.tensor("dyD", dyD, dy), k.tensor("dbD", dbD, db)
+	k.samedtype(dyt, dbt)
+	k.bias(dbt, dyt)
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionBackwardBias(c, alpha, dyD, dy, beta, dbD, db)
+	}
+	return withdesc(err, "c", c, "dyD", dyD, "dbD", dbD)
+}
+
+//GetFwdWorkspaceSize - Query the workspace size required for a forward convolution layer
+//
+//This call is required and must be executed once before running
+//(*ConvolutionD)FindForwardAlgorithm()
+//in order to determine the largest required allocation for the algorithm search; i.e., the maximum
+//size of the memory needed from the set of potential forward convolution algorithm is returned.
+//
+//If using Group/Depthwise convolution mode, call miopenSetConvolutionGroupCount() before running
+//this.
+//
+//	h		MIOpen handle (input)
+//
+//	wD		Tensor descriptor for weight tensor w (input)
+//
+//	xD		Tensor descriptor for input data tensor x (input)
+//
+//	yD		Tensor descriptor for output data tensor y (input)
+func (c *ConvolutionD) GetFwdWorkspaceSize(h *Handle, wD, xD, yD *TensorD) (wspaceSIB uint, err error) {
+	k := check{op: "(*ConvolutionD)GetFwdWorkspaceSize()"}
+	k.conv(c, k.desc("xD", xD), k.desc("wD", wD), k.desc("yD", yD))
+	if k.err == nil {
+		wspaceSIB, err = h.b.ConvolutionForwardGetWorkSpaceSize(c, wD, xD, yD)
+	} else {
+		err = k.err
+	}
+	return wspaceSIB, withdesc(err, "c", c, "wD", wD, "xD", xD, "yD", yD)
+}
+
+//FindForwardAlgorithm - Search and run the forward convolutional algorithms and return a list of kernel times.
+//
+// This function attempts all MIOpen forward convolution algorithms based on
+// the input configuration, and outputs performance metrics to a
+// slice of type ConvFwdAlgoPerf. These metrics are written
+// in a sorted fashion where the first element has the lowest compute time.
+// Users can chose the top-most algorithm if they only care about the fastest
+// algorithm.
+//
+// This function is mandatory before using (*ConvolutionD)Forward(). In order
+// to execute this function, (*ConvolutionD)GetFwdWorkSpaceSize() must be
+// run to determine the required memory for this search.
+//
+// MIOpen will look for the best kernel for the provided configuration.
+// If a match is not found, an exhaustive search is performed by running individual algorithms.
+//
+// If using Group/Depthwise convolution mode, call (*ConvolutionD)SetGroupCount() before running
+// this.
+//
+//	h			MIOpen handle (input)
+//
+//	xD			Tensor descriptor for data input tensor x (input)
+//
+//	x			Data tensor x (input)
+//
+//	wD			Tensor descriptor for weight tensor w (input)
+//
+//	w			Weights tensor w (input)
+//
+//	yD			Tensor descriptor for output data tensor y (input)
+//
+//	y			Data tensor y (output)
+//
+//	wspace			Pointer to workspace required for the search (input)
+//
+//	wspaceSIB		Size in bytes of the memory needed for find (input)
+//
+//	opts			Optional, only the first is used. The default is FindOptions{} (input)
+func (c *ConvolutionD) FindForwardAlgorithm(
+	h *Handle,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts ...FindOptions) (results []ConvFwdAlgoPerf, err error) {
+	k := check{op: "(*ConvolutionD)FindForwardAlgorithm()"}
+	k.conv(c, k.tensor("xD", xD, x), k.tensor("wD", wD, w), k.tensor("yD", yD, y))
+	k.wspace(wspace, wspaceSIB)
+	if k.err == nil {
+		results, err = h.findforward(c, xD, x, wD, w, yD, y, wspace, wspaceSIB, findoptions(opts))
+	} else {
+		err = k.err
+	}
+	return results, withdesc(err, "c", c, "xD", xD, "wD", wD, "yD", yD)
+}
+
+//GetBwdDataWorkspaceSize -  Get the GPU memory required for the backward data convolution algorithm.
+//
+//For a provided tensor descriptors and algorithm selection, this function calculates and returns
+//the workspace size required for back propagation on data. This call is required and must be
+//executed once before running (*ConvolutionD)FindBwdDataAlgorithm() in order to determine
+//the largest required allocation for the algorithm search; i.e., the maximum size of the memory
+//needed from the set of potential backward convolution algorithm is returned.
+//
+//If using Group/Depthwise convolution mode, call miopenSetConvolutionGroupCount() before running
+//this.
+//
+//	h         MIOpen handle (input)
+//	dyD         Tensor descriptor for data input tensor dy (input)
+//	wD          Tensor descriptor for weight tensor w (input)
+//	dxD         Tensor descriptor for output data tensor dx (input)
+//
+func (c *ConvolutionD) GetBwdDataWorkspaceSize(h *Handle, dyD, wD, dxD *TensorD) (wspaceSIB uint, err error) {
+	k := check{op: "(*ConvolutionD)GetBwdDataWorkspaceSize()"}
+	k.conv(c, k.desc("dxD", dxD), k.desc("wD", wD), k.desc("dyD", dyD))
+	if k.err == nil {
+		wspaceSIB, err = h.b.ConvolutionBackwardDataGetWorkSpaceSize(c, dyD, wD, dxD)
+	} else {
+		err = k.err
+	}
+	return wspaceSIB, withdesc(err, "c", c, "dyD", dyD, "wD", wD, "dxD", dxD)
+}
+
+//FindBwdDataAlgorithm - Search and run the backwards data convolution algorithms and return a list of kernel times.
+//
+//This function attempts all MIOpen backward data convolution algorithms, and returns a slice of
+//type ConvBwdDataAlgoPerf.  These metrics are written in sorted fashion where the first
+//element has the lowest compute time.  This function is mandatory before using backwards
+//convolutions. Users can chose the top-most algorithm if they only care about the fastest algorithm.
+//
+//This function is mandatory before using (*ConvolutionD)BackwardData(). In order to
+//execute this function, (*ConvolutionD)GetBackwardDataWorkSpaceSize() must be run to determine
+//the required memory for this search.
+//
+// MIOpen will look for the best kernel for the provided configuration.
+// If a match is not found, an exhaustive search is performed by running individual algorithms.
+//
+//If using Group/Depthwise convolution mode, call (*ConvolutionD)SetGroupCount() before running
+//this.
+//
+//If h is in deterministic mode the algorithms that aren't deterministic are dropped from the results.
+//
+//	h			MIOpen handle (input)
+//	dyD			Tensor descriptor for data input tensor dy (input)
+//	dy			Data delta tensor dy (input)
+//	wD			Tensor descriptor for weight tensor w (input)
+//	w			Weights tensor w (input)
+//	dxD			Tensor descriptor for output data tensor dx (input)
+//	dx			Data delta tensor dx (input)
+//	wspace			Pointer to workspace required for the search (output)
+//	wspaceSIB		Size in bytes of the memory needed for find (output)
+//	opts			Optional, only the first is used. The default is FindOptions{} (input)
+func (c *ConvolutionD) FindBwdDataAlgorithm(
+	h *Handle,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts ...FindOptions) (results []ConvBwdDataAlgoPerf, err error) {
+	k := check{op: "(*ConvolutionD)FindBwdDataAlgorithm()"}
+	k.conv(c, k.tensor("dxD", dxD, dx), k.tensor("wD", wD, w), k.tensor("dyD", dyD, dy))
+	k.wspace(wspace, wspaceSIB)
+	k.setdeterministic(h, c)
+	if k.err == nil {
+		results, err = h.findbackwarddata(c, dyD, dy, wD, w, dxD, dx, wspace, wspaceSIB, findoptions(opts))
+	} else {
+		err = k.err
+	}
+	return results, withdesc(err, "c", c, "dyD", dyD, "wD", wD, "dxD", dxD)
+}
+
+//GetBwdWeightsWorkspaceSize - Get the GPU memory required for the backward weights convolution algorithm.
+//
+//For a provided tensor descriptors and algorithm selection, this function calculates and returns
+//the workspace size required for back propagation on data. This call is required and must be
+//executed once before running (*ConvolutionD)FindBwdWeightsAlgorithm() in order to
+//determine
+//the largest required allocation for the algorithm search; i.e., the maximum size of the memory
+//needed from the set of potential backward weights convolution algorithm is returned.
+//
+//If using Group/Depthwise convolution mode, call (*ConvolutionD)SetGroupCount() before running
+//this.
+//
+//	h		MIOpen handle (input)
+//	dyD		Tensor descriptor for data input tensor dy (input)
+//	xD		Tensor descriptor for data tensor x (input)
+//	dwD		Tensor descriptor for output weights tensor dw (input)
+func (c *ConvolutionD) GetBwdWeightsWorkspaceSize(h *Handle, dyD, xD, dwD *TensorD) (wspaceSIB uint, err error) {
+	k := check{op: "(*ConvolutionD)GetBwdWeightsWorkspaceSize()"}
+	k.conv(c, k.desc("xD", xD), k.desc("dwD", dwD), k.desc("dyD", dyD))
+	if k.err == nil {
+		wspaceSIB, err = h.b.ConvolutionBackwardWeightsGetWorkSpaceSize(c, dyD, xD, dwD)
+	} else {
+		err = k.err
+	}
+	return wspaceSIB, withdesc(err, "c", c, "dyD", dyD, "xD", xD, "dwD", dwD)
+}
+
+//FindBwdWeightsAlgorithm - Search and run the backwards weights convolutional algorithms and return a list of kernel times.
+//
+//This function attempts all MIOpen backward weights convolution algorithms, and returns a slice of
+//type ConvBwdWeightAlgoPerf. These metrics are written in sorted fashion where the first element has
+//the lowest compute time.  This function is mandatory before using backwards weight convolutions.
+//Users can chose the top-most algorithm if they only care about the fastest algorithm.
+//
+//This function is mandatory before using (*ConvolutionD)BackwardWeights(). In order to
+//execute this function, (*ConvolutionD)GetBwdWeightsWorkSpaceSize() must be run to
+//determine the required memory for this search.
+//
+// MIOpen will look for the best kernel for the provided configuration.
+// If a match is not found, an exhaustive search is performed by running individual algorithms.
+//
+// If using Group/Depthwise convolution mode, call (*Convolution)SetGroupCount() before running
+// this.
+//
+//If h is in deterministic mode the algorithms that aren't deterministic are dropped from the results.
+//
+//h		MIOpen handle (input)
+//dyD		Tensor descriptor for data input tensor dy (input)
+//dy		Data delta tensor dy (input)
+//xD		Tensor descriptor for output data tensor x (input)
+//x		Data delta tensor dx (input)
+//dwD		Tensor descriptor for weight tensor dw (input)
+//dw		Weights delta tensor dw (input)
+//workSpace		Pointer to workspace required for the search (input)
+//workSpaceSize		Size in bytes of the memory needed for find (input)
+//opts		Optional, only the first is used. The default is FindOptions{} (input)
+func (c *ConvolutionD) FindBwdWeightsAlgorithm(h *Handle,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts ...FindOptions) (results []ConvBwdWeightAlgoPerf, err error) {
+	k := check{op: "(*ConvolutionD)FindBwdWeightsAlgorithm()"}
+	k.conv(c, k.tensor("xD", xD, x), k.tensor("dwD", dwD, dw), k.tensor("dyD", dyD, dy))
+	k.wspace(wspace, wspaceSIB)
+	k.setdeterministic(h, c)
+	if k.err == nil {
+		results, err = h.findbackwardweights(c, dyD, dy, xD, x, dwD, dw, wspace, wspaceSIB, findoptions(opts))
+	} else {
+		err = k.err
+	}
+	return results, withdesc(err, "c", c, "dyD", dyD, "xD", xD, "dwD", dwD)
+}
@@ -0,0 +1,61 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"testing"
+
+	"github.com/dereklstinson/cutil"
+	miopen "github.com/dereklstinson/migo"
+)
+
+//nofusion is a backend without ConvolutionBiasActivationForward
+type nofusion struct {
+	miopen.Backend
+}
+
+func (nofusion) ConvolutionBiasActivationForward(c *miopen.ConvolutionD, alpha1 float64,
+	xD *miopen.TensorD, x cutil.Mem,
+	wD *miopen.TensorD, w cutil.Mem,
+	algo miopen.ConvFwdAlgorithm,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha2 float64,
+	zD *miopen.TensorD, z cutil.Mem,
+	bD *miopen.TensorD, b cutil.Mem,
+	a *miopen.ActivationD,
+	yD *miopen.TensorD, y cutil.Mem) error {
+	return miopen.ErrNotImplemented
+}
+
+func TestForwardBiasActivation(t *testing.T) {
+	c, xD, wD, yD := convfixture(t)
+	bD := tensor(t, 1, 1, 1, 1)
+	a, err := miopen.CreateActivationDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var amode miopen.ActivationMode
+	if err = a.Set(amode.Relu(), 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	x, w := convdata()
+	var algo miopen.ConvFwdAlgorithm
+	for _, b := range []miopen.Backend{miopen.NewCPUBackend(), nofusion{miopen.NewCPUBackend()}} {
+		h, err := miopen.NewHandle(miopen.WithBackend(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		//conv(x, w) is {6, 8, 12, 14}, and y is also the residual z
+		y := floats{1, -1, 1, -1}
+		err = c.ForwardBiasActivation(h, 1, xD, x, wD, w, algo.Direct(), nil, 0, 1, yD, y, bD, floats{-10}, a, yD, y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range []float32{0, 0, 3, 3} {
+			if y[i] != v {
+				t.Fatalf("%T: unexpected output %v", b, y)
+			}
+		}
+	}
+}
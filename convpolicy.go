@@ -0,0 +1,335 @@
+package miopen
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//ConvPolicy picks the algorithm a convolution runs with out of the results of Find.  The zero value picks
+//the fastest algorithm.
+//
+//A ConvolutionD carries one set with SetPolicy, and uses it in ForwardAuto, BackwardDataAuto and
+//BackwardWeightsAuto.  It can also be used on its own with PickFwd, PickBwdData and PickBwdWeights.
+type ConvPolicy struct {
+	//MaxWorkspace drops algorithms that need more than MaxWorkspace bytes of workspace. 0 is no limit.
+	MaxWorkspace uint
+	//Deterministic drops the algorithms that can accumulate with atomics and give slightly different results
+	//from run to run.  Those are the implicit GEMM kernels of the backward passes.
+	Deterministic bool
+	//The Prefer lists are tried in order, and the first algorithm that Find returned (and that isn't dropped)
+	//is used.  If none of them are available the fastest algorithm is used.
+	PreferFwd        []ConvFwdAlgorithm
+	PreferBwdData    []ConvBwdDataAlgorithm
+	PreferBwdWeights []ConvBwdWeightsAlgorithm
+}
+
+//implicitgemm is the value of the ImplicitGEMM flag of every algorithm type, and transposegemm the value of
+//ConvBwdDataAlgorithm's TransposeGEMM flag
+const (
+	implicitgemm  = 5
+	transposegemm = 4
+)
+
+//PickFwd returns the algorithm and workspace size the policy picks out of perfs.
+func (p ConvPolicy) PickFwd(perfs []ConvFwdAlgoPerf) (algo ConvFwdAlgorithm, wspaceSIB uint, err error) {
+	choice, err := p.pick(fwdtraceperfs(perfs), p.preferfwd(), false)
+	return ConvFwdAlgorithm(choice.Algo), choice.Memory, err
+}
+
+//PickBwdData returns the algorithm and workspace size the policy picks out of perfs.
+func (p ConvPolicy) PickBwdData(perfs []ConvBwdDataAlgoPerf) (algo ConvBwdDataAlgorithm, wspaceSIB uint, err error) {
+	choice, err := p.pick(bwddatatraceperfs(perfs), p.preferbwddata(), true)
+	return ConvBwdDataAlgorithm(choice.Algo), choice.Memory, err
+}
+
+//PickBwdWeights returns the algorithm and workspace size the policy picks out of perfs.
+func (p ConvPolicy) PickBwdWeights(perfs []ConvBwdWeightAlgoPerf) (algo ConvBwdWeightsAlgorithm, wspaceSIB uint, err error) {
+	choice, err := p.pick(bwdweighttraceperfs(perfs), p.preferbwdweights(), true)
+	return ConvBwdWeightsAlgorithm(choice.Algo), choice.Memory, err
+}
+
+func (p ConvPolicy) preferfwd() []int32 {
+	prefer := make([]int32, len(p.PreferFwd))
+	for i := range p.PreferFwd {
+		prefer[i] = int32(p.PreferFwd[i])
+	}
+	return prefer
+}
+
+func (p ConvPolicy) preferbwddata() []int32 {
+	prefer := make([]int32, len(p.PreferBwdData))
+	for i := range p.PreferBwdData {
+		prefer[i] = int32(p.PreferBwdData[i])
+	}
+	return prefer
+}
+
+func (p ConvPolicy) preferbwdweights() []int32 {
+	prefer := make([]int32, len(p.PreferBwdWeights))
+	for i := range p.PreferBwdWeights {
+		prefer[i] = int32(p.PreferBwdWeights[i])
+	}
+	return prefer
+}
+
+//pick drops the perfs the policy doesn't allow and returns the first preferred algorithm left, or the fastest.
+func (p ConvPolicy) pick(perfs []traceperf, prefer []int32, backward bool) (traceperf, error) {
+	kept := make([]traceperf, 0, len(perfs))
+	for _, perf := range perfs {
+		if p.MaxWorkspace != 0 && perf.Memory > p.MaxWorkspace {
+			continue
+		}
+		if p.Deterministic && backward && nondeterministic(perf.Algo) {
+			continue
+		}
+		kept = append(kept, perf)
+	}
+	if len(kept) == 0 {
+		return traceperf{}, statusNotImplemented.error(fmt.Sprintf("(ConvPolicy)Pick: none of the %d algorithms found fit the policy %+v", len(perfs), p))
+	}
+	for _, algo := range prefer {
+		for _, perf := range kept {
+			if perf.Algo == algo {
+				return perf, nil
+			}
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].Time < kept[j].Time })
+	return kept[0], nil
+}
+
+//convpolicy is the policy of a ConvolutionD and the algorithms it picked for the shapes it has seen.  The
+//zero value is the zero ConvPolicy.
+type convpolicy struct {
+	mux     sync.Mutex
+	policy  ConvPolicy
+	gen     int //gen counts the SetPolicy calls, so a pick made with an older policy isn't kept
+	choices map[choicekey]traceperf
+}
+
+//set sets the policy and forgets the algorithms already picked
+func (p *convpolicy) set(policy ConvPolicy) {
+	p.mux.Lock()
+	p.policy, p.choices = policy, nil
+	p.gen++
+	p.mux.Unlock()
+}
+
+//get returns the policy, the algorithm picked for key if there is one, and the generation of the policy
+func (p *convpolicy) get(key choicekey) (policy ConvPolicy, choice traceperf, hit bool, gen int) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	choice, hit = p.choices[key]
+	return p.policy, choice, hit, p.gen
+}
+
+//keep keeps the algorithm picked for key, unless the policy changed since gen
+func (p *convpolicy) keep(key choicekey, gen int, choice traceperf) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if gen != p.gen {
+		return
+	}
+	if p.choices == nil {
+		p.choices = make(map[choicekey]traceperf)
+	}
+	p.choices[key] = choice
+}
+
+//choicekey is the key of a pick.  It is comparable, so the Auto functions don't marshal a searchkey on every
+//call.  It has the handle's deterministic mode, since a pick made outside of deterministic mode may not be
+//allowed in it.
+type choicekey struct {
+	op                    string
+	device                string
+	dtype                 DataType
+	x, w, y               dimskey
+	xstride, wstride      dimskey
+	ystride               dimskey
+	pad, stride, dilation dimskey
+	groups                int32
+	mode                  ConvolutionMode
+	deterministic         bool
+}
+
+//dimskey is a comparable copy of up to 8 dims
+type dimskey struct {
+	n    int
+	dims [8]int32
+}
+
+//set copies dims to k.  It returns false if there are too many.
+func (k *dimskey) set(dims []int32) bool {
+	if len(dims) > len(k.dims) {
+		return false
+	}
+	k.n = copy(k.dims[:], dims)
+	return true
+}
+
+//newchoicekey returns the key of a search.  ok is false if a descriptor can't be read.
+func newchoicekey(h *Handle, op string, c *ConvolutionD, xD, wD, yD *TensorD) (key choicekey, ok bool) {
+	key = choicekey{op: op, device: h.devicename(), groups: c.groupcount(), deterministic: h.deterministic}
+	pad, stride, dilation, mode, err := c.Get()
+	if err != nil {
+		return key, false
+	}
+	key.mode = mode
+	ok = key.pad.set(pad) && key.stride.set(stride) && key.dilation.set(dilation)
+	for _, t := range []struct {
+		d              *TensorD
+		shape, strides *dimskey
+	}{{xD, &key.x, &key.xstride}, {wD, &key.w, &key.wstride}, {yD, &key.y, &key.ystride}} {
+		dtype, shape, strides, err := t.d.Get()
+		if err != nil {
+			return key, false
+		}
+		if t.d == xD {
+			key.dtype = dtype
+		}
+		ok = ok && t.shape.set(shape) && t.strides.set(strides)
+	}
+	return key, ok
+}
+
+//SetPolicy sets the policy used by the Auto functions.  The algorithms already picked are forgotten.
+func (c *ConvolutionD) SetPolicy(p ConvPolicy) {
+	c.policy.set(p)
+}
+
+//Policy returns the policy set with SetPolicy
+func (c *ConvolutionD) Policy() ConvPolicy {
+	c.policy.mux.Lock()
+	defer c.policy.mux.Unlock()
+	return c.policy.policy
+}
+
+//search finds the algorithms for a convolution and picks one with c's policy.  The pick is kept, so search
+//only runs Find the first time it sees the shapes of xD, wD and yD in the handle's deterministic mode.
+//
+//Find writes to the output, so if beta isn't 0 it is given a scratch output instead of out.
+func (c *ConvolutionD) search(h *Handle, op string,
+	xD, wD, yD *TensorD,
+	outD *TensorD, out cutil.Mem, beta float64,
+	prefer func(ConvPolicy) []int32, backward bool,
+	wsize func() (uint, error),
+	find func(wspace cutil.Mem, wspaceSIB uint, out cutil.Mem, opts FindOptions) ([]traceperf, error)) (traceperf, error) {
+	key, ok := newchoicekey(h, op, c, xD, wD, yD)
+	policy, choice, hit, gen := c.policy.get(key)
+	if ok && hit {
+		return choice, nil
+	}
+	opts := FindOptions{Request: 8}
+	wspaceSIB, err := wsize()
+	if err != nil {
+		return traceperf{}, err
+	}
+	wspace, err := h.Workspace().Get(wspaceSIB)
+	if err != nil {
+		return traceperf{}, err
+	}
+	if beta != 0 {
+		scratch := NewWorkspace(h.Workspace().a)
+		defer scratch.Free()
+		sib, err := outD.GetSIB()
+		if err != nil {
+			return traceperf{}, err
+		}
+		if out, err = scratch.Get(sib); err != nil {
+			return traceperf{}, err
+		}
+	}
+	perfs, err := find(wspace, wspaceSIB, out, opts)
+	if err != nil {
+		return traceperf{}, err
+	}
+	choice, err = policy.pick(perfs, prefer(policy), backward)
+	if err != nil {
+		return traceperf{}, err
+	}
+	if ok {
+		c.policy.keep(key, gen, choice)
+	}
+	return choice, nil
+}
+
+//ForwardAuto is Forward with the algorithm picked by c's policy and the workspace taken from h.Workspace().
+//
+//The first call for a set of shapes runs FindForwardAlgorithm.
+func (c *ConvolutionD) ForwardAuto(h *Handle,
+	alpha float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	choice, err := c.search(h, "forward", xD, wD, yD, yD, y, beta, ConvPolicy.preferfwd, false,
+		func() (uint, error) { return c.GetFwdWorkspaceSize(h, wD, xD, yD) },
+		func(wspace cutil.Mem, wspaceSIB uint, y cutil.Mem, opts FindOptions) ([]traceperf, error) {
+			perfs, err := c.FindForwardAlgorithm(h, xD, x, wD, w, yD, y, wspace, wspaceSIB, opts)
+			return fwdtraceperfs(perfs), err
+		})
+	if err != nil {
+		return err
+	}
+	wspace, err := h.Workspace().Get(choice.Memory)
+	if err != nil {
+		return err
+	}
+	algo := ConvFwdAlgorithm(choice.Algo)
+	return c.Forward(h, alpha, xD, x, wD, w, &algo, beta, yD, y, wspace, choice.Memory)
+}
+
+//BackwardDataAuto is BackwardData with the algorithm picked by c's policy and the workspace taken from
+//h.Workspace().
+//
+//The first call for a set of shapes runs FindBwdDataAlgorithm.
+func (c *ConvolutionD) BackwardDataAuto(h *Handle,
+	alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	choice, err := c.search(h, "backwarddata", dxD, wD, dyD, dxD, dx, beta, ConvPolicy.preferbwddata, true,
+		func() (uint, error) { return c.GetBwdDataWorkspaceSize(h, dyD, wD, dxD) },
+		func(wspace cutil.Mem, wspaceSIB uint, dx cutil.Mem, opts FindOptions) ([]traceperf, error) {
+			perfs, err := c.FindBwdDataAlgorithm(h, dyD, dy, wD, w, dxD, dx, wspace, wspaceSIB, opts)
+			return bwddatatraceperfs(perfs), err
+		})
+	if err != nil {
+		return err
+	}
+	wspace, err := h.Workspace().Get(choice.Memory)
+	if err != nil {
+		return err
+	}
+	return c.BackwardData(h, alpha, dyD, dy, wD, w, ConvBwdDataAlgorithm(choice.Algo), beta, dxD, dx, wspace, choice.Memory)
+}
+
+//BackwardWeightsAuto is BackwardWeights with the algorithm picked by c's policy and the workspace taken from
+//h.Workspace().
+//
+//The first call for a set of shapes runs FindBwdWeightsAlgorithm.
+func (c *ConvolutionD) BackwardWeightsAuto(h *Handle,
+	alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dwD *TensorD, dw cutil.Mem) error {
+	choice, err := c.search(h, "backwardweights", xD, dwD, dyD, dwD, dw, beta, ConvPolicy.preferbwdweights, true,
+		func() (uint, error) { return c.GetBwdWeightsWorkspaceSize(h, dyD, xD, dwD) },
+		func(wspace cutil.Mem, wspaceSIB uint, dw cutil.Mem, opts FindOptions) ([]traceperf, error) {
+			perfs, err := c.FindBwdWeightsAlgorithm(h, dyD, dy, xD, x, dwD, dw, wspace, wspaceSIB, opts)
+			return bwdweighttraceperfs(perfs), err
+		})
+	if err != nil {
+		return err
+	}
+	wspace, err := h.Workspace().Get(choice.Memory)
+	if err != nil {
+		return err
+	}
+	return c.BackwardWeights(h, alpha, dyD, dy, xD, x, ConvBwdWeightsAlgorithm(choice.Algo), beta, dwD, dw, wspace, choice.Memory)
+}
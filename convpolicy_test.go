@@ -0,0 +1,65 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"sync"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestConvPolicy(t *testing.T) {
+	h := miopen.CreateHandle()
+	c, xD, wD, yD := convfixture(t)
+	var gemm miopen.ConvFwdAlgorithm
+	c.SetPolicy(miopen.ConvPolicy{MaxWorkspace: 1 << 20, Deterministic: true, PreferFwd: []miopen.ConvFwdAlgorithm{gemm.GEMM()}})
+	x, w := convdata()
+	y := floats{1, 1, 1, 1}
+	if err := c.ForwardAuto(h, 1, xD, x, wD, w, 1, yD, y); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range []float32{7, 9, 13, 15} {
+		if y[i] != v {
+			t.Fatal("expected Find to leave y alone when beta is 1, got", y)
+		}
+	}
+	dx := make(floats, 9)
+	if err := c.BackwardDataAuto(h, 1, yD, floats{1, 1, 1, 1}, wD, w, 0, xD, dx); err != nil {
+		t.Fatal(err)
+	}
+	if dx[4] != 2 {
+		t.Fatal("unexpected backward data", dx)
+	}
+}
+
+//TestConvPolicyRace runs the Auto functions of one descriptor from several goroutines while its policy is
+//set, run it with -race.
+func TestConvPolicyRace(t *testing.T) {
+	c, xD, wD, yD := convfixture(t)
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				c.SetPolicy(miopen.ConvPolicy{MaxWorkspace: uint(i)})
+			}
+			h := miopen.CreateHandle()
+			x, w, y := make(floats, 9), make(floats, 4), make(floats, 4)
+			for j := 0; j < 10; j++ {
+				if err := c.ForwardAuto(h, 1, xD, x, wD, w, 0, yD, y); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,111 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"testing"
+	"unsafe"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+//floats is host memory used by the cpu build
+type floats []float32
+
+func (f floats) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(&f[0])
+}
+func (f floats) DPtr() *unsafe.Pointer {
+	p := f.Ptr()
+	return &p
+}
+
+//tensor returns a float descriptor of dims
+func tensor(t *testing.T, dims ...int32) *miopen.TensorD {
+	tD, err := miopen.CreateTensorDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dtype miopen.DataType
+	if err = tD.Set(dtype.Float(), dims, nil); err != nil {
+		t.Fatal(err)
+	}
+	return tD
+}
+
+//convfixture returns the convolution most tests run: no padding, stride 1 and dilation 1 over a 1x1x3x3 x
+//and a 1x1x2x2 w, which gives a 1x1x2x2 y.
+func convfixture(t *testing.T) (c *miopen.ConvolutionD, xD, wD, yD *miopen.TensorD) {
+	c, err := miopen.CreateConvolutionDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mode miopen.ConvolutionMode
+	if err = c.Set([]int32{0, 0}, []int32{1, 1}, []int32{1, 1}, mode.Convolution()); err != nil {
+		t.Fatal(err)
+	}
+	return c, tensor(t, 1, 1, 3, 3), tensor(t, 1, 1, 2, 2), tensor(t, 1, 1, 2, 2)
+}
+
+//convdata returns an x and a w for convfixture, conv(x, w) is {6, 8, 12, 14}
+func convdata() (x, w floats) {
+	return floats{1, 2, 3, 4, 5, 6, 7, 8, 9}, floats{1, 0, 0, 1}
+}
+
+func TestCPUConvolution(t *testing.T) {
+	h := miopen.CreateHandle()
+	c, xD, wD, yD := convfixture(t)
+	dims, err := c.ForwardOutputDim(xD, wD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equal(dims, []int32{1, 1, 2, 2}) {
+		t.Fatal("unexpected output dims", dims)
+	}
+	x, w := convdata()
+	y := make(floats, 4)
+	var algo miopen.ConvFwdAlgorithm
+	algo.Direct()
+	if err = c.Forward(h, 1, xD, x, wD, w, &algo, 0, yD, y, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range []float32{6, 8, 12, 14} {
+		if y[i] != v {
+			t.Fatal("unexpected output", y)
+		}
+	}
+	dx := make(floats, 9)
+	var balgo miopen.ConvBwdDataAlgorithm
+	if err = c.BackwardData(h, 1, yD, floats{1, 1, 1, 1}, wD, w, balgo.Direct(), 0, xD, dx, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range []float32{1, 1, 0, 1, 2, 1, 0, 1, 1} {
+		if dx[i] != v {
+			t.Fatal("unexpected backward data", dx)
+		}
+	}
+}
+
+func TestCPUActivation(t *testing.T) {
+	h := miopen.CreateHandle()
+	xD := tensor(t, 1, 4, 1, 1)
+	a, err := miopen.CreateActivationDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mode miopen.ActivationMode
+	if err = a.Set(mode.Relu(), 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	x := floats{-1, 2, -3, 4}
+	y := make(floats, 4)
+	if err = a.Forward(h, 1, xD, x, 0, xD, y); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range []float32{0, 2, 0, 4} {
+		if y[i] != v {
+			t.Fatal("unexpected output", y)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package miopen
+
+import (
+	"math"
+	"time"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//cpuBackend runs every operation with go code on host memory.
+type cpuBackend struct {
+	profiling bool
+	ktime     float32
+}
+
+//NewCPUBackend returns a Backend that runs every operation in go on host memory.
+//
+//It is the default backend of the nomiopen and cpu builds.  In the rocm build it can be passed to
+//(*Handle)SetBackend() to check results, but then all cutil.Mem used with the handle must point to
+//host memory.  Workspaces are never used and Find only returns the Direct algorithm.
+func NewCPUBackend() Backend {
+	return new(cpuBackend)
+}
+
+//EnableProfiling - Enables profiling to retrieve kernel time
+func (cb *cpuBackend) EnableProfiling(enable bool) error {
+	cb.profiling = enable
+	return nil
+}
+
+//GetKernelTime - returns the time in ms of the last operation if profiling is enabled.
+func (cb *cpuBackend) GetKernelTime() (time float32, err error) {
+	if !cb.profiling {
+		return 0, statusNotInitialized.error("GetKernelTime")
+	}
+	return cb.ktime, nil
+}
+
+//timed is deferred by operations with time.Now() so GetKernelTime reports their run time.
+func (cb *cpuBackend) timed(start time.Time) {
+	if cb.profiling {
+		cb.ktime = float32(time.Since(start).Seconds() * 1000)
+	}
+}
+
+func (cb *cpuBackend) SetTensor(t *TensorD, tmem cutil.Mem, alpha float64) error {
+	defer cb.timed(time.Now())
+	v, err := viewof(t, tmem, "SetAll")
+	if err != nil {
+		return err
+	}
+	vals := make([]float64, v.volume())
+	for i := range vals {
+		vals[i] = alpha
+	}
+	v.store(vals, 1, 0)
+	return nil
+}
+
+func (cb *cpuBackend) ScaleTensor(t *TensorD, tmem cutil.Mem, alpha float64) error {
+	defer cb.timed(time.Now())
+	v, err := viewof(t, tmem, "Scale")
+	if err != nil {
+		return err
+	}
+	v.store(v.load(), alpha, 0)
+	return nil
+}
+
+//LogTensor - t = ln(t)
+func (cb *cpuBackend) LogTensor(t *TensorD, tmem cutil.Mem) error {
+	defer cb.timed(time.Now())
+	v, err := viewof(t, tmem, "Log")
+	if err != nil {
+		return err
+	}
+	vals := v.load()
+	for i := range vals {
+		vals[i] = math.Log(vals[i])
+	}
+	v.store(vals, 1, 0)
+	return nil
+}
+
+//TransformTensor - y = alpha*x + beta*y
+func (cb *cpuBackend) TransformTensor(alpha float64, xD *TensorD, x cutil.Mem, beta float64, yD *TensorD, y cutil.Mem) error {
+	defer cb.timed(time.Now())
+	xv, err := viewof(xD, x, "TransformTensor")
+	if err != nil {
+		return err
+	}
+	yv, err := viewof(yD, y, "TransformTensor")
+	if err != nil {
+		return err
+	}
+	if !comparedims(xv.shape, yv.shape) {
+		return statusBadParm.error("TransformTensor: x and y shapes differ")
+	}
+	yv.store(xv.load(), alpha, beta)
+	return nil
+}
+
+//OpTensor - C = op ( alpha1[0] * A, alpha2[0] * B ) + beta[0] * C
+//
+//Each dimension of A and B must either match C or be 1.
+func (cb *cpuBackend) OpTensor(op OpTensorOp,
+	alpha float64, aD *TensorD, a cutil.Mem,
+	alpha2 float64, bD *TensorD, b cutil.Mem,
+	beta float64, cD *TensorD, c cutil.Mem) error {
+	defer cb.timed(time.Now())
+	av, err := viewof(aD, a, "OpTensor")
+	if err != nil {
+		return err
+	}
+	bv, err := viewof(bD, b, "OpTensor")
+	if err != nil {
+		return err
+	}
+	cv, err := viewof(cD, c, "OpTensor")
+	if err != nil {
+		return err
+	}
+	amap, ok := broadcastmap(cv.shape, av.shape)
+	if !ok {
+		return statusBadParm.error("OpTensor: A not broadcastable to C")
+	}
+	bmap, ok := broadcastmap(cv.shape, bv.shape)
+	if !ok {
+		return statusBadParm.error("OpTensor: B not broadcastable to C")
+	}
+	avals, bvals := av.load(), bv.load()
+	out := make([]float64, cv.volume())
+	var flg OpTensorOp
+	for i := range out {
+		x, y := alpha*avals[amap[i]], alpha2*bvals[bmap[i]]
+		switch op {
+		case flg.Add():
+			out[i] = x + y
+		case flg.Mul():
+			out[i] = x * y
+		case flg.Min():
+			out[i] = math.Min(x, y)
+		case flg.Max():
+			out[i] = math.Max(x, y)
+		default:
+			return statusBadParm.error("OpTensor: unknown OpTensorOp")
+		}
+	}
+	cv.store(out, 1, beta)
+	return nil
+}
@@ -0,0 +1,157 @@
+package miopen
+
+import (
+	"math"
+	"time"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//activationparams holds everything an ActivationD was set with.
+type activationparams struct {
+	mode               ActivationMode
+	alpha, beta, gamma float64
+}
+
+func (a *ActivationD) params() (p activationparams, err error) {
+	p.mode, p.alpha, p.beta, p.gamma, err = a.Get()
+	return p, err
+}
+
+//ActivationForward - y = alpha*f(x) + beta*y
+func (cb *cpuBackend) ActivationForward(a *ActivationD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	defer cb.timed(time.Now())
+	d, err := a.params()
+	if err != nil {
+		return err
+	}
+	xv, err := viewof(xD, x, "(a *Activation)Forward()")
+	if err != nil {
+		return err
+	}
+	yv, err := viewof(yD, y, "(a *Activation)Forward()")
+	if err != nil {
+		return err
+	}
+	if !comparedims(xv.shape, yv.shape) {
+		return statusBadParm.error("(a *Activation)Forward(): x and y shapes differ")
+	}
+	vals := xv.load()
+	var flg ActivationMode
+	for i, v := range vals {
+		switch d.mode {
+		case flg.PasThru():
+		case flg.Logistic():
+			vals[i] = 1 / (1 + math.Exp(-v))
+		case flg.Tanh():
+			vals[i] = d.beta * math.Tanh(d.alpha*v)
+		case flg.Relu():
+			vals[i] = math.Max(0, v)
+		case flg.SoftRelu():
+			vals[i] = math.Log1p(math.Exp(v))
+		case flg.Abs():
+			vals[i] = math.Abs(v)
+		case flg.Power():
+			vals[i] = math.Pow(d.alpha+d.beta*v, d.gamma)
+		case flg.ClippedRelu():
+			vals[i] = math.Min(d.alpha, math.Max(0, v))
+		case flg.LeakyRelu():
+			if v <= 0 {
+				vals[i] = d.alpha * v
+			}
+		case flg.Elu():
+			if v <= 0 {
+				vals[i] = d.alpha * math.Expm1(v)
+			}
+		default:
+			return statusBadParm.error("(a *Activation)Forward(): unknown ActivationMode")
+		}
+	}
+	yv.store(vals, alpha, beta)
+	return nil
+}
+
+//ActivationBackward - dx = alpha*f'(x)*dy + beta*dx
+func (cb *cpuBackend) ActivationBackward(a *ActivationD, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	defer cb.timed(time.Now())
+	d, err := a.params()
+	if err != nil {
+		return err
+	}
+	const comment = "(a *Activation)Backward()"
+	yv, err := viewof(yD, y, comment)
+	if err != nil {
+		return err
+	}
+	dyv, err := viewof(dyD, dy, comment)
+	if err != nil {
+		return err
+	}
+	xv, err := viewof(xD, x, comment)
+	if err != nil {
+		return err
+	}
+	dxv, err := viewof(dxD, dx, comment)
+	if err != nil {
+		return err
+	}
+	if !comparedims(xv.shape, yv.shape, dyv.shape, dxv.shape) {
+		return statusBadParm.error(comment + ": tensor shapes differ")
+	}
+	xs, ys, dys := xv.load(), yv.load(), dyv.load()
+	dxs := make([]float64, len(xs))
+	var flg ActivationMode
+	for i := range xs {
+		v, out, g := xs[i], ys[i], dys[i]
+		switch d.mode {
+		case flg.PasThru():
+			dxs[i] = g
+		case flg.Logistic():
+			dxs[i] = g * out * (1 - out)
+		case flg.Tanh():
+			dxs[i] = g * d.alpha * (d.beta - out*out/d.beta)
+		case flg.Relu():
+			if v > 0 {
+				dxs[i] = g
+			}
+		case flg.SoftRelu():
+			dxs[i] = g / (1 + math.Exp(-v))
+		case flg.Abs():
+			if v > 0 {
+				dxs[i] = g
+			} else if v < 0 {
+				dxs[i] = -g
+			}
+		case flg.Power():
+			dxs[i] = g * d.gamma * d.beta * math.Pow(d.alpha+d.beta*v, d.gamma-1)
+		case flg.ClippedRelu():
+			if v > 0 && v <= d.alpha {
+				dxs[i] = g
+			}
+		case flg.LeakyRelu():
+			if v > 0 {
+				dxs[i] = g
+			} else {
+				dxs[i] = g * d.alpha
+			}
+		case flg.Elu():
+			if v > 0 {
+				dxs[i] = g
+			} else {
+				dxs[i] = g * (out + d.alpha)
+			}
+		default:
+			return statusBadParm.error(comment + ": unknown ActivationMode")
+		}
+	}
+	dxv.store(dxs, alpha, beta)
+	return nil
+}
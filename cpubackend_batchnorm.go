@@ -0,0 +1,245 @@
+package miopen
+
+import (
+	"math"
+	"time"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//BatchNormalizationForwardInference -  Execute forward inference layer for batch normalization
+//
+//If either mean, variance are nil then the mean and variance of x are used.
+func (cb *cpuBackend) BatchNormalizationForwardInference(b *BatchNormD, alpha, beta float64,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	scalbiasmeanvarD *TensorD,
+	scale, bias cutil.Mem, //returned values
+	mean, variance cutil.Mem, //returned values
+	epsilon float64,
+) error {
+	defer cb.timed(time.Now())
+	const comment = "(b *BatchNormD)ForwardInference"
+	xv, yv, pmap, pvol, err := bnviews(comment, xD, x, yD, y, scalbiasmeanvarD)
+	if err != nil {
+		return err
+	}
+	if scale == nil || bias == nil {
+		return statusBadParm.error(comment + ": nil scale or bias")
+	}
+	if mean == nil || variance == nil {
+		mean, variance = nil, nil
+	}
+	_, p, err := loadparams(comment, scalbiasmeanvarD, scale, bias, mean, variance)
+	if err != nil {
+		return err
+	}
+	xs := xv.load()
+	if mean == nil {
+		p[2], p[3] = bnstats(xs, pmap, pvol)
+	}
+	ys := make([]float64, len(xs))
+	for i, v := range xs {
+		j := pmap[i]
+		ys[i] = p[0][j]*(v-p[2][j])/math.Sqrt(p[3][j]+epsilon) + p[1][j]
+	}
+	yv.store(ys, alpha, beta)
+	return nil
+}
+
+//BatchNormalizationForwardTraining - Execute forward training layer for batch normalization
+//
+//If either saveMean, or saveInvariance are nil then the values for the mean and inverse variance
+//will not be saved. Likewise, if either mean, or variance are nil then the running mean and variance
+//will not be updated.
+//
+//	M.old =M.new*factor + M.old*(1-factor)
+func (cb *cpuBackend) BatchNormalizationForwardTraining(b *BatchNormD, alpha, beta float64,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	scalbiasmeanvarD *TensorD,
+	scale, bias cutil.Mem, //returned values
+	avgfactor float64,
+	mean, variance cutil.Mem, //returned values
+	epsilon float64,
+	saveMean, saveInvariance cutil.Mem, //returned vallues
+) error {
+	defer cb.timed(time.Now())
+	const comment = "(b *BatchNormD)ForwardTraining"
+	xv, yv, pmap, pvol, err := bnviews(comment, xD, x, yD, y, scalbiasmeanvarD)
+	if err != nil {
+		return err
+	}
+	if scale == nil || bias == nil {
+		return statusBadParm.error(comment + ": nil scale or bias")
+	}
+	if mean == nil || variance == nil {
+		mean, variance = nil, nil
+	}
+	if saveMean == nil || saveInvariance == nil {
+		saveMean, saveInvariance = nil, nil
+	}
+	pv, p, err := loadparams(comment, scalbiasmeanvarD, scale, bias, mean, variance, saveMean, saveInvariance)
+	if err != nil {
+		return err
+	}
+	xs := xv.load()
+	bmean, bvar := bnstats(xs, pmap, pvol)
+	ys := make([]float64, len(xs))
+	for i, v := range xs {
+		j := pmap[i]
+		ys[i] = p[0][j]*(v-bmean[j])/math.Sqrt(bvar[j]+epsilon) + p[1][j]
+	}
+	yv.store(ys, alpha, beta)
+	if mean != nil {
+		m := float64(len(xs) / pvol)
+		unbiased := make([]float64, pvol)
+		for i := range bvar {
+			unbiased[i] = bvar[i]
+			if m > 1 {
+				unbiased[i] = bvar[i] * m / (m - 1)
+			}
+		}
+		pv[2].store(bmean, avgfactor, 1-avgfactor)
+		pv[3].store(unbiased, avgfactor, 1-avgfactor)
+	}
+	if saveMean != nil {
+		inv := make([]float64, pvol)
+		for i := range bvar {
+			inv[i] = 1 / math.Sqrt(bvar[i]+epsilon)
+		}
+		pv[4].store(bmean, 1, 0)
+		pv[5].store(inv, 1, 0)
+	}
+	return nil
+}
+
+//BatchNormalizationBackward - Execute backwards propagation layer for batch normalization
+//
+//If BOTH savedMean, and savedVariance are not nil then the method will use the saved
+//mean and inverse variance calculated by the forward training phase.
+func (cb *cpuBackend) BatchNormalizationBackward(b *BatchNormD, alphaDataDiff, betaDataDiff, alphaParamDiff, betaParamDiff float64,
+	xD *TensorD, x cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	scalebiasdiffD *TensorD,
+	scale, scalediff, biasdiff cutil.Mem,
+	epsilon float64,
+	savedMean, savedInvVariance cutil.Mem) error {
+	defer cb.timed(time.Now())
+	const comment = "(b *BatchNormD)Backward()"
+	xv, dyv, pmap, pvol, err := bnviews(comment, xD, x, dyD, dy, scalebiasdiffD)
+	if err != nil {
+		return err
+	}
+	dxv, err := viewof(dxD, dx, comment)
+	if err != nil {
+		return err
+	}
+	if !comparedims(xv.shape, dxv.shape) {
+		return statusBadParm.error(comment + ": x and dx shapes differ")
+	}
+	if scale == nil || scalediff == nil || biasdiff == nil {
+		return statusBadParm.error(comment + ": nil scale, scalediff or biasdiff")
+	}
+	if savedMean == nil || savedInvVariance == nil {
+		savedMean, savedInvVariance = nil, nil
+	}
+	pv, p, err := loadparams(comment, scalebiasdiffD, scale, scalediff, biasdiff, savedMean, savedInvVariance)
+	if err != nil {
+		return err
+	}
+	xs, dys := xv.load(), dyv.load()
+	bmean, invstd := p[3], p[4]
+	if savedMean == nil {
+		var bvar []float64
+		bmean, bvar = bnstats(xs, pmap, pvol)
+		invstd = make([]float64, pvol)
+		for i := range bvar {
+			invstd[i] = 1 / math.Sqrt(bvar[i]+epsilon)
+		}
+	}
+	dscale := make([]float64, pvol)
+	dbias := make([]float64, pvol)
+	for i, v := range xs {
+		j := pmap[i]
+		dbias[j] += dys[i]
+		dscale[j] += dys[i] * (v - bmean[j]) * invstd[j]
+	}
+	m := float64(len(xs) / pvol)
+	dxs := make([]float64, len(xs))
+	for i, v := range xs {
+		j := pmap[i]
+		xhat := (v - bmean[j]) * invstd[j]
+		dxs[i] = p[0][j] * invstd[j] / m * (m*dys[i] - dbias[j] - xhat*dscale[j])
+	}
+	dxv.store(dxs, alphaDataDiff, betaDataDiff)
+	pv[1].store(dscale, alphaParamDiff, betaParamDiff)
+	pv[2].store(dbias, alphaParamDiff, betaParamDiff)
+	return nil
+}
+
+//bnstats returns the per parameter mean and biased variance of xs
+func bnstats(xs []float64, pmap []int, pvol int) (mean, variance []float64) {
+	mean = make([]float64, pvol)
+	variance = make([]float64, pvol)
+	m := float64(len(xs) / pvol)
+	for i, v := range xs {
+		mean[pmap[i]] += v
+	}
+	for i := range mean {
+		mean[i] /= m
+	}
+	for i, v := range xs {
+		d := v - mean[pmap[i]]
+		variance[pmap[i]] += d * d
+	}
+	for i := range variance {
+		variance[i] /= m
+	}
+	return mean, variance
+}
+
+//bnviews loads the x, y and scale/bias/mean/variance descriptor and checks they agree
+func bnviews(comment string, xD *TensorD, x cutil.Mem, yD *TensorD, y cutil.Mem, pD *TensorD) (xv, yv *hostview, pmap []int, pvol int, err error) {
+	xv, err = viewof(xD, x, comment)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	yv, err = viewof(yD, y, comment)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	if !comparedims(xv.shape, yv.shape) {
+		return nil, nil, nil, 0, statusBadParm.error(comment + ": x and y shapes differ")
+	}
+	if pD == nil {
+		return nil, nil, nil, 0, statusBadParm.error(comment + ": scale bias descriptor not set")
+	}
+	_, pshape, _, err := pD.Get()
+	if err != nil {
+		return nil, nil, nil, 0, statusBadParm.error(comment + ": scale bias descriptor not set")
+	}
+	pmap, ok := broadcastmap(xv.shape, pshape)
+	if !ok {
+		return nil, nil, nil, 0, statusBadParm.error(comment + ": scale bias descriptor does not match x")
+	}
+	return xv, yv, pmap, int(findvolume(pshape)), nil
+}
+
+//loadparams loads every param memory that isn't nil with the shared descriptor pD.
+func loadparams(comment string, pD *TensorD, mems ...cutil.Mem) ([]*hostview, [][]float64, error) {
+	views := make([]*hostview, len(mems))
+	vals := make([][]float64, len(mems))
+	for i, m := range mems {
+		if m == nil {
+			continue
+		}
+		v, err := viewof(pD, m, comment)
+		if err != nil {
+			return nil, nil, err
+		}
+		views[i], vals[i] = v, v.load()
+	}
+	return views, vals, nil
+}
@@ -0,0 +1,534 @@
+package miopen
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//convparams holds everything a ConvolutionD was set with.
+type convparams struct {
+	pad, stride, dilation []int32
+	adj                   []int32
+	mode                  ConvolutionMode
+	groups                int32
+}
+
+func (c *ConvolutionD) params() (p convparams, err error) {
+	p.pad, p.stride, p.dilation, p.mode, err = c.Get()
+	if err != nil {
+		return p, err
+	}
+	if len(p.pad) == 0 {
+		return p, statusBadParm.error("(*ConvolutionD): descriptor not set")
+	}
+	p.groups = c.groupcount()
+	p.adj = c.outputpadding()
+	if len(p.adj) != len(p.pad) {
+		p.adj = make([]int32, len(p.pad))
+	}
+	return p, nil
+}
+
+//outputdim returns the shape of y for x and w.
+func (p *convparams) outputdim(x, w []int32) ([]int32, error) {
+	g := ConvolutionGeometry{Pad: p.pad, Stride: p.stride, Dilation: p.dilation, Mode: p.mode, Groups: p.groups, OutputPadding: p.adj}
+	return g.OutputDim(x, w)
+}
+
+//convwalk calls f with the packed index of every x, w and y element that are multiplied together
+//in a cross-correlation where x is [N,C,...], w is [K,C/G,...] and y is [N,K,...].
+func (p *convparams) convwalk(xshape, wshape, yshape []int32, f func(xi, wi, yi int)) error {
+	xs, ws, ys := stridecalc(xshape), stridecalc(wshape), stridecalc(yshape)
+	cg, kg := int(wshape[1]), int(wshape[0]/p.groups)
+	if kg == 0 {
+		return statusBadParm.error(fmt.Sprintf("(*ConvolutionD): w %v has fewer filters than the %d groups", wshape, p.groups))
+	}
+	in := make([]int32, len(p.pad))
+	for n := 0; n < int(xshape[0]); n++ {
+		for k := 0; k < int(wshape[0]); k++ {
+			g := k / kg
+			forEachIndex(yshape[2:], func(o []int32) {
+				yi := n*int(ys[0]) + k*int(ys[1])
+				for j := range o {
+					yi += int(o[j]) * int(ys[j+2])
+				}
+				for ch := 0; ch < cg; ch++ {
+					forEachIndex(wshape[2:], func(r []int32) {
+						xi := n*int(xs[0]) + (g*cg+ch)*int(xs[1])
+						wi := k*int(ws[0]) + ch*int(ws[1])
+						for j := range r {
+							in[j] = o[j]*p.stride[j] - p.pad[j] + r[j]*p.dilation[j]
+							if in[j] < 0 || in[j] >= xshape[j+2] {
+								return
+							}
+							xi += int(in[j]) * int(xs[j+2])
+							wi += int(r[j]) * int(ws[j+2])
+						}
+						f(xi, wi, yi)
+					})
+				}
+			})
+		}
+	}
+	return nil
+}
+
+//walk is convwalk for the descriptor's mode. xi and yi always index the x and y of a forward pass.
+//A transpose convolution is the backward data pass of a convolution with x and y swapped.
+func (p *convparams) walk(xshape, wshape, yshape []int32, f func(xi, wi, yi int)) error {
+	var flg ConvolutionMode
+	if p.mode == flg.Transpose() {
+		return p.convwalk(yshape, wshape, xshape, func(xi, wi, yi int) { f(yi, wi, xi) })
+	}
+	return p.convwalk(xshape, wshape, yshape, f)
+}
+
+//convcheck returns the params of c after checking that y is the output shape of x and w.
+func convcheck(c *ConvolutionD, xshape, wshape, yshape []int32, comment string) (convparams, error) {
+	p, err := c.params()
+	if err != nil {
+		return p, err
+	}
+	dims, err := p.outputdim(xshape, wshape)
+	if err != nil {
+		return p, statusBadParm.error(comment + ": " + err.Error())
+	}
+	if !comparedims(dims, yshape) {
+		return p, statusBadParm.error(comment + ": y shape does not match the convolution output shape")
+	}
+	return p, nil
+}
+
+//shapesof returns the shape of every descriptor in ds
+func shapesof(comment string, ds ...*TensorD) ([][]int32, error) {
+	shapes := make([][]int32, len(ds))
+	for i, d := range ds {
+		if d == nil {
+			return nil, statusBadParm.error(comment + ": nil tensor descriptor")
+		}
+		_, shape, _, err := d.Get()
+		if err != nil {
+			return nil, err
+		}
+		shapes[i] = shape
+	}
+	return shapes, nil
+}
+
+//elapsed returns the milliseconds since start
+func elapsed(start time.Time) float32 {
+	return float32(time.Since(start).Seconds() * 1000)
+}
+
+//ConvolutionForwardGetWorkSpaceSize always returns 0. The cpu backend doesn't use a workspace.
+func (cb *cpuBackend) ConvolutionForwardGetWorkSpaceSize(c *ConvolutionD, wD, xD, yD *TensorD) (wspaceSIB uint, err error) {
+	s, err := shapesof("GetFwdWorkSpaceSize", xD, wD, yD)
+	if err != nil {
+		return 0, err
+	}
+	_, err = convcheck(c, s[0], s[1], s[2], "GetFwdWorkSpaceSize")
+	return 0, err
+}
+
+//FindConvolutionForwardAlgorithm runs the Direct algorithm once and returns its time.
+func (cb *cpuBackend) FindConvolutionForwardAlgorithm(c *ConvolutionD,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvFwdAlgoPerf, error) {
+	var algo ConvFwdAlgorithm
+	algo.Direct()
+	start := time.Now()
+	err := cb.ConvolutionForward(c, 1, xD, x, wD, w, algo, 0, yD, y, wspace, wspaceSIB)
+	if err != nil {
+		return nil, err
+	}
+	return []ConvFwdAlgoPerf{newConvFwdAlgoPerf(algo, elapsed(start), 0)}, nil
+}
+
+//ConvolutionForward - y = alpha*conv(x,w) + beta*y
+//
+//Every algo runs a direct convolution.
+func (cb *cpuBackend) ConvolutionForward(c *ConvolutionD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvFwdAlgorithm,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	defer cb.timed(time.Now())
+	const comment = "(c *ConvolutionD)Forward()"
+	xv, err := viewof(xD, x, comment)
+	if err != nil {
+		return err
+	}
+	wv, err := viewof(wD, w, comment)
+	if err != nil {
+		return err
+	}
+	yv, err := viewof(yD, y, comment)
+	if err != nil {
+		return err
+	}
+	p, err := convcheck(c, xv.shape, wv.shape, yv.shape, comment)
+	if err != nil {
+		return err
+	}
+	xs, ws := xv.load(), wv.load()
+	acc := make([]float64, yv.volume())
+	err = p.walk(xv.shape, wv.shape, yv.shape, func(xi, wi, yi int) {
+		acc[yi] += xs[xi] * ws[wi]
+	})
+	if err != nil {
+		return err
+	}
+	yv.store(acc, alpha, beta)
+	return nil
+}
+
+//ConvolutionForwardBias - y = alpha*b + beta*y
+func (cb *cpuBackend) ConvolutionForwardBias(c *ConvolutionD, alpha float64,
+	bD *TensorD, b cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	defer cb.timed(time.Now())
+	const comment = "(c *ConvolutionD)ForwardBias()"
+	bv, err := viewof(bD, b, comment)
+	if err != nil {
+		return err
+	}
+	yv, err := viewof(yD, y, comment)
+	if err != nil {
+		return err
+	}
+	bmap, ok := broadcastmap(yv.shape, bv.shape)
+	if !ok {
+		return statusBadParm.error(comment + ": b not broadcastable to y")
+	}
+	bs := bv.load()
+	vals := make([]float64, len(bmap))
+	for i := range vals {
+		vals[i] = bs[bmap[i]]
+	}
+	yv.store(vals, alpha, beta)
+	return nil
+}
+
+//ConvolutionBiasActivationForward runs ConvolutionForward, ConvolutionForwardBias and ActivationForward.
+func (cb *cpuBackend) ConvolutionBiasActivationForward(c *ConvolutionD, alpha1 float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvFwdAlgorithm,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha2 float64,
+	zD *TensorD, z cutil.Mem,
+	bD *TensorD, b cutil.Mem,
+	a *ActivationD,
+	yD *TensorD, y cutil.Mem) error {
+	return biasactivation(cb, c, alpha1, xD, x, wD, w, algo, wspace, wspaceSIB, alpha2, zD, z, bD, b, a, yD, y)
+}
+
+//ConvolutionBackwardDataGetWorkSpaceSize always returns 0. The cpu backend doesn't use a workspace.
+func (cb *cpuBackend) ConvolutionBackwardDataGetWorkSpaceSize(c *ConvolutionD, dyD, wD, dxD *TensorD) (wspaceSIB uint, err error) {
+	const comment = "(c *ConvolutionD)GetBackwardDataWorkSpaceSize"
+	s, err := shapesof(comment, dxD, wD, dyD)
+	if err != nil {
+		return 0, err
+	}
+	_, err = convcheck(c, s[0], s[1], s[2], comment)
+	return 0, err
+}
+
+//FindConvolutionBackwardDataAlgorithm runs the Direct algorithm once and returns its time.
+func (cb *cpuBackend) FindConvolutionBackwardDataAlgorithm(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvBwdDataAlgoPerf, error) {
+	var algo ConvBwdDataAlgorithm
+	algo.Direct()
+	start := time.Now()
+	err := cb.ConvolutionBackwardData(c, 1, dyD, dy, wD, w, algo, 0, dxD, dx, wspace, wspaceSIB)
+	if err != nil {
+		return nil, err
+	}
+	return []ConvBwdDataAlgoPerf{newConvBwdDataAlgoPerf(algo, elapsed(start), 0)}, nil
+}
+
+//ConvolutionBackwardData - dx = alpha*conv'(dy,w) + beta*dx
+func (cb *cpuBackend) ConvolutionBackwardData(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvBwdDataAlgorithm,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	defer cb.timed(time.Now())
+	const comment = "(c *ConvolutionD)BackwardData()"
+	if c.deterministic && nondeterministic(int32(algo)) {
+		return statusBadParm.error(fmt.Sprintf("%s: algorithm %d isn't deterministic", comment, algo))
+	}
+	dyv, err := viewof(dyD, dy, comment)
+	if err != nil {
+		return err
+	}
+	wv, err := viewof(wD, w, comment)
+	if err != nil {
+		return err
+	}
+	dxv, err := viewof(dxD, dx, comment)
+	if err != nil {
+		return err
+	}
+	p, err := convcheck(c, dxv.shape, wv.shape, dyv.shape, comment)
+	if err != nil {
+		return err
+	}
+	dys, ws := dyv.load(), wv.load()
+	acc := make([]float64, dxv.volume())
+	err = p.walk(dxv.shape, wv.shape, dyv.shape, func(xi, wi, yi int) {
+		acc[xi] += dys[yi] * ws[wi]
+	})
+	if err != nil {
+		return err
+	}
+	dxv.store(acc, alpha, beta)
+	return nil
+}
+
+//ConvolutionBackwardWeightsGetWorkSpaceSize always returns 0. The cpu backend doesn't use a workspace.
+func (cb *cpuBackend) ConvolutionBackwardWeightsGetWorkSpaceSize(c *ConvolutionD, dyD, xD, dwD *TensorD) (wspaceSIB uint, err error) {
+	s, err := shapesof("GetBwdWeightsWorkspaceSize", xD, dwD, dyD)
+	if err != nil {
+		return 0, err
+	}
+	_, err = convcheck(c, s[0], s[1], s[2], "GetBwdWeightsWorkspaceSize")
+	return 0, err
+}
+
+//FindConvolutionBackwardWeightsAlgorithm runs the Direct algorithm once and returns its time.
+func (cb *cpuBackend) FindConvolutionBackwardWeightsAlgorithm(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvBwdWeightAlgoPerf, error) {
+	var algo ConvBwdWeightsAlgorithm
+	algo.Direct()
+	start := time.Now()
+	err := cb.ConvolutionBackwardWeights(c, 1, dyD, dy, xD, x, algo, 0, dwD, dw, wspace, wspaceSIB)
+	if err != nil {
+		return nil, err
+	}
+	return []ConvBwdWeightAlgoPerf{newConvBwdWeightAlgoPerf(algo, elapsed(start), 0)}, nil
+}
+
+//ConvolutionBackwardWeights - dw = alpha*conv'(dy,x) + beta*dw
+func (cb *cpuBackend) ConvolutionBackwardWeights(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	algo ConvBwdWeightsAlgorithm,
+	beta float64,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	defer cb.timed(time.Now())
+	const comment = "(c *ConvolutionD)BackwardWeights()"
+	if c.deterministic && nondeterministic(int32(algo)) {
+		return statusBadParm.error(fmt.Sprintf("%s: algorithm %d isn't deterministic", comment, algo))
+	}
+	dyv, err := viewof(dyD, dy, comment)
+	if err != nil {
+		return err
+	}
+	xv, err := viewof(xD, x, comment)
+	if err != nil {
+		return err
+	}
+	dwv, err := viewof(dwD, dw, comment)
+	if err != nil {
+		return err
+	}
+	p, err := convcheck(c, xv.shape, dwv.shape, dyv.shape, comment)
+	if err != nil {
+		return err
+	}
+	dys, xs := dyv.load(), xv.load()
+	acc := make([]float64, dwv.volume())
+	err = p.walk(xv.shape, dwv.shape, dyv.shape, func(xi, wi, yi int) {
+		acc[wi] += xs[xi] * dys[yi]
+	})
+	if err != nil {
+		return err
+	}
+	dwv.store(acc, alpha, beta)
+	return nil
+}
+
+//ConvolutionBackwardBias - db = alpha*sum(dy) + beta*db
+func (cb *cpuBackend) ConvolutionBackwardBias(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	beta float64,
+	dbD *TensorD, db cutil.Mem) error {
+	defer cb.timed(time.Now())
+	const comment = "(c *ConvolutionD)BackwardBias()"
+	dyv, err := viewof(dyD, dy, comment)
+	if err != nil {
+		return err
+	}
+	dbv, err := viewof(dbD, db, comment)
+	if err != nil {
+		return err
+	}
+	bmap, ok := broadcastmap(dyv.shape, dbv.shape)
+	if !ok {
+		return statusBadParm.error(comment + ": db not broadcastable to dy")
+	}
+	acc := make([]float64, dbv.volume())
+	for i, v := range dyv.load() {
+		acc[bmap[i]] += v
+	}
+	dbv.store(acc, alpha, beta)
+	return nil
+}
+
+//cpusolution is the only immediate mode solution of the cpu backend
+var cpusolution = ConvSolution{ID: 1, Algorithm: ConvAlgorithm(1)} //1 is Direct
+
+//checksolution checks the descriptors of an immediate mode call and that solutionID is the cpu solution.
+func (cb *cpuBackend) checksolution(c *ConvolutionD, xD, wD, yD *TensorD, solutionID uint64, comment string) error {
+	s, err := shapesof(comment, xD, wD, yD)
+	if err != nil {
+		return err
+	}
+	if _, err = convcheck(c, s[0], s[1], s[2], comment); err != nil {
+		return err
+	}
+	if solutionID != cpusolution.ID {
+		return statusBadParm.error(fmt.Sprintf("%s: unknown solution %d", comment, solutionID))
+	}
+	return nil
+}
+
+//ConvolutionForwardGetSolutionCount returns 1, the direct convolution
+func (cb *cpuBackend) ConvolutionForwardGetSolutionCount(c *ConvolutionD, wD, xD, yD *TensorD) (uint, error) {
+	if err := cb.checksolution(c, xD, wD, yD, cpusolution.ID, "(*ConvolutionD)GetFwdSolutionCount()"); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+//ConvolutionForwardGetSolution returns the direct convolution if max isn't 0
+func (cb *cpuBackend) ConvolutionForwardGetSolution(c *ConvolutionD, wD, xD, yD *TensorD, max uint) ([]ConvSolution, error) {
+	if err := cb.checksolution(c, xD, wD, yD, cpusolution.ID, "(*ConvolutionD)GetFwdSolutions()"); err != nil || max == 0 {
+		return nil, err
+	}
+	return []ConvSolution{cpusolution}, nil
+}
+
+//ConvolutionForwardGetSolutionWorkspaceSize always returns 0. The cpu backend doesn't use a workspace.
+func (cb *cpuBackend) ConvolutionForwardGetSolutionWorkspaceSize(c *ConvolutionD, wD, xD, yD *TensorD, solutionID uint64) (uint, error) {
+	return 0, cb.checksolution(c, xD, wD, yD, solutionID, "(*ConvolutionD)GetFwdSolutionWorkspaceSize()")
+}
+
+//ConvolutionForwardCompileSolution only checks its arguments, there is nothing to compile.
+func (cb *cpuBackend) ConvolutionForwardCompileSolution(c *ConvolutionD, wD, xD, yD *TensorD, solutionID uint64) error {
+	return cb.checksolution(c, xD, wD, yD, solutionID, "(*ConvolutionD)CompileFwdSolution()")
+}
+
+//ConvolutionForwardImmediate runs the direct convolution
+func (cb *cpuBackend) ConvolutionForwardImmediate(c *ConvolutionD,
+	wD *TensorD, w cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	if solutionID != cpusolution.ID {
+		return statusBadParm.error(fmt.Sprintf("(*ConvolutionD)ForwardImmediate(): unknown solution %d", solutionID))
+	}
+	var algo ConvFwdAlgorithm
+	return cb.ConvolutionForward(c, 1, xD, x, wD, w, algo.Direct(), 0, yD, y, wspace, wspaceSIB)
+}
+
+//ConvolutionBackwardDataGetSolutionCount returns 1, the direct convolution
+func (cb *cpuBackend) ConvolutionBackwardDataGetSolutionCount(c *ConvolutionD, dyD, wD, dxD *TensorD) (uint, error) {
+	if err := cb.checksolution(c, dxD, wD, dyD, cpusolution.ID, "(*ConvolutionD)GetBwdDataSolutionCount()"); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+//ConvolutionBackwardDataGetSolution returns the direct convolution if max isn't 0
+func (cb *cpuBackend) ConvolutionBackwardDataGetSolution(c *ConvolutionD, dyD, wD, dxD *TensorD, max uint) ([]ConvSolution, error) {
+	if err := cb.checksolution(c, dxD, wD, dyD, cpusolution.ID, "(*ConvolutionD)GetBwdDataSolutions()"); err != nil || max == 0 {
+		return nil, err
+	}
+	return []ConvSolution{cpusolution}, nil
+}
+
+//ConvolutionBackwardDataGetSolutionWorkspaceSize always returns 0. The cpu backend doesn't use a workspace.
+func (cb *cpuBackend) ConvolutionBackwardDataGetSolutionWorkspaceSize(c *ConvolutionD, dyD, wD, dxD *TensorD, solutionID uint64) (uint, error) {
+	return 0, cb.checksolution(c, dxD, wD, dyD, solutionID, "(*ConvolutionD)GetBwdDataSolutionWorkspaceSize()")
+}
+
+//ConvolutionBackwardDataCompileSolution only checks its arguments, there is nothing to compile.
+func (cb *cpuBackend) ConvolutionBackwardDataCompileSolution(c *ConvolutionD, dyD, wD, dxD *TensorD, solutionID uint64) error {
+	return cb.checksolution(c, dxD, wD, dyD, solutionID, "(*ConvolutionD)CompileBwdDataSolution()")
+}
+
+//ConvolutionBackwardDataImmediate runs the direct convolution
+func (cb *cpuBackend) ConvolutionBackwardDataImmediate(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	if solutionID != cpusolution.ID {
+		return statusBadParm.error(fmt.Sprintf("(*ConvolutionD)BackwardDataImmediate(): unknown solution %d", solutionID))
+	}
+	var algo ConvBwdDataAlgorithm
+	return cb.ConvolutionBackwardData(c, 1, dyD, dy, wD, w, algo.Direct(), 0, dxD, dx, wspace, wspaceSIB)
+}
+
+//ConvolutionBackwardWeightsGetSolutionCount returns 1, the direct convolution
+func (cb *cpuBackend) ConvolutionBackwardWeightsGetSolutionCount(c *ConvolutionD, dyD, xD, dwD *TensorD) (uint, error) {
+	if err := cb.checksolution(c, xD, dwD, dyD, cpusolution.ID, "(*ConvolutionD)GetBwdWeightsSolutionCount()"); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+//ConvolutionBackwardWeightsGetSolution returns the direct convolution if max isn't 0
+func (cb *cpuBackend) ConvolutionBackwardWeightsGetSolution(c *ConvolutionD, dyD, xD, dwD *TensorD, max uint) ([]ConvSolution, error) {
+	if err := cb.checksolution(c, xD, dwD, dyD, cpusolution.ID, "(*ConvolutionD)GetBwdWeightsSolutions()"); err != nil || max == 0 {
+		return nil, err
+	}
+	return []ConvSolution{cpusolution}, nil
+}
+
+//ConvolutionBackwardWeightsGetSolutionWorkspaceSize always returns 0. The cpu backend doesn't use a workspace.
+func (cb *cpuBackend) ConvolutionBackwardWeightsGetSolutionWorkspaceSize(c *ConvolutionD, dyD, xD, dwD *TensorD, solutionID uint64) (uint, error) {
+	return 0, cb.checksolution(c, xD, dwD, dyD, solutionID, "(*ConvolutionD)GetBwdWeightsSolutionWorkspaceSize()")
+}
+
+//ConvolutionBackwardWeightsCompileSolution only checks its arguments, there is nothing to compile.
+func (cb *cpuBackend) ConvolutionBackwardWeightsCompileSolution(c *ConvolutionD, dyD, xD, dwD *TensorD, solutionID uint64) error {
+	return cb.checksolution(c, xD, dwD, dyD, solutionID, "(*ConvolutionD)CompileBwdWeightsSolution()")
+}
+
+//ConvolutionBackwardWeightsImmediate runs the direct convolution
+func (cb *cpuBackend) ConvolutionBackwardWeightsImmediate(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	if solutionID != cpusolution.ID {
+		return statusBadParm.error(fmt.Sprintf("(*ConvolutionD)BackwardWeightsImmediate(): unknown solution %d", solutionID))
+	}
+	var algo ConvBwdWeightsAlgorithm
+	return cb.ConvolutionBackwardWeights(c, 1, dyD, dy, xD, x, algo.Direct(), 0, dwD, dw, wspace, wspaceSIB)
+}
@@ -0,0 +1,159 @@
+package miopen
+
+import (
+	"math"
+	"time"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//lrnparams holds everything a LRND was set with.
+type lrnparams struct {
+	mode           LRNMode
+	n              uint32
+	alpha, beta, k float64
+}
+
+func (l *LRND) params() (lp lrnparams, err error) {
+	lp.mode, lp.n, lp.alpha, lp.beta, lp.k, err = l.Get()
+	if err == nil && lp.n == 0 {
+		err = statusBadParm.error("(l *LRND): descriptor not set")
+	}
+	return lp, err
+}
+
+//lrnscale returns the normalization window of every element and its scale
+//	k + alpha/N * sum(x^2) over the window
+func (l *lrnparams) lrnscale(shape []int32, xs []float64) (windows [][]int, scale []float64) {
+	n, c, hh, ww := int(shape[0]), int(shape[1]), int(shape[2]), int(shape[3])
+	r := int(l.n-1) / 2
+	var flg LRNMode
+	cross := l.mode == flg.CrossChannel()
+	divisor := float64(l.n)
+	if !cross {
+		divisor *= float64(l.n)
+	}
+	windows = make([][]int, len(xs))
+	scale = make([]float64, len(xs))
+	for b := 0; b < n; b++ {
+		for ch := 0; ch < c; ch++ {
+			for y := 0; y < hh; y++ {
+				for x := 0; x < ww; x++ {
+					i := ((b*c+ch)*hh+y)*ww + x
+					var win []int
+					if cross {
+						for wc := ch - r; wc <= ch+r; wc++ {
+							if wc >= 0 && wc < c {
+								win = append(win, ((b*c+wc)*hh+y)*ww+x)
+							}
+						}
+					} else {
+						for wy := y - r; wy <= y+r; wy++ {
+							for wx := x - r; wx <= x+r; wx++ {
+								if wy >= 0 && wy < hh && wx >= 0 && wx < ww {
+									win = append(win, ((b*c+ch)*hh+wy)*ww+wx)
+								}
+							}
+						}
+					}
+					var sum float64
+					for _, j := range win {
+						sum += xs[j] * xs[j]
+					}
+					windows[i] = win
+					scale[i] = l.k + l.alpha/divisor*sum
+				}
+			}
+		}
+	}
+	return windows, scale
+}
+
+//LRNForward - doBackwards and wspace are ignored by the cpu backend.
+func (cb *cpuBackend) LRNForward(l *LRND, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	doBackwards bool, wspace cutil.Mem) error {
+	defer cb.timed(time.Now())
+	const comment = " (l *LRND)Forward()"
+	lp, err := l.params()
+	if err != nil {
+		return err
+	}
+	xv, err := viewof(xD, x, comment)
+	if err != nil {
+		return err
+	}
+	yv, err := viewof(yD, y, comment)
+	if err != nil {
+		return err
+	}
+	if len(xv.shape) != 4 || !comparedims(xv.shape, yv.shape) {
+		return statusBadParm.error(comment + ": x and y must be 4d tensors of the same shape")
+	}
+	xs := xv.load()
+	_, scale := lp.lrnscale(xv.shape, xs)
+	ys := make([]float64, len(xs))
+	for i := range xs {
+		ys[i] = xs[i] * math.Pow(scale[i], -lp.beta)
+	}
+	yv.store(ys, alpha, beta)
+	return nil
+}
+
+//LRNBackward - dx = alpha*lrn'(x,y,dy) + beta*dx
+func (cb *cpuBackend) LRNBackward(l *LRND, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem) error {
+	defer cb.timed(time.Now())
+	const comment = "(l *LRND)Backward()"
+	lp, err := l.params()
+	if err != nil {
+		return err
+	}
+	yv, err := viewof(yD, y, comment)
+	if err != nil {
+		return err
+	}
+	dyv, err := viewof(dyD, dy, comment)
+	if err != nil {
+		return err
+	}
+	xv, err := viewof(xD, x, comment)
+	if err != nil {
+		return err
+	}
+	dxv, err := viewof(dxD, dx, comment)
+	if err != nil {
+		return err
+	}
+	if len(xv.shape) != 4 || !comparedims(xv.shape, yv.shape, dyv.shape, dxv.shape) {
+		return statusBadParm.error(comment + ": tensors must be 4d and of the same shape")
+	}
+	xs, ys, dys := xv.load(), yv.load(), dyv.load()
+	windows, scale := lp.lrnscale(xv.shape, xs)
+	divisor := float64(lp.n)
+	var flg LRNMode
+	if lp.mode != flg.CrossChannel() {
+		divisor *= float64(lp.n)
+	}
+	ratio := make([]float64, len(xs))
+	for i := range xs {
+		ratio[i] = dys[i] * ys[i] / scale[i]
+	}
+	dxs := make([]float64, len(xs))
+	for i := range xs {
+		var sum float64
+		for _, j := range windows[i] {
+			sum += ratio[j]
+		}
+		dxs[i] = dys[i]*math.Pow(scale[i], -lp.beta) - 2*lp.alpha*lp.beta/divisor*xs[i]*sum
+	}
+	dxv.store(dxs, alpha, beta)
+	return nil
+}
@@ -0,0 +1,166 @@
+package miopen
+
+import (
+	"math"
+	"time"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//poolingparams holds everything a PoolingD was set with.
+type poolingparams struct {
+	mode                PoolingMode
+	window, pad, stride []int32
+}
+
+func (p *PoolingD) params() (pp poolingparams, err error) {
+	pp.mode, pp.window, pp.pad, pp.stride, err = p.Get()
+	if err == nil && len(pp.window) != 2 {
+		err = statusBadParm.error("(p *PoolingD): descriptor not set")
+	}
+	return pp, err
+}
+
+//poolwalk calls f for every output element with the packed indexes of the valid input
+//elements that are inside of its window.  count is the divisor used by average pooling.
+func (p *poolingparams) poolwalk(xshape, yshape []int32, f func(yidx int, xidx []int, count int)) {
+	n, c := int(yshape[0]), int(yshape[1])
+	ih, iw := int(xshape[2]), int(xshape[3])
+	oh, ow := int(yshape[2]), int(yshape[3])
+	var flg PoolingMode
+	inclusive := p.mode == flg.AverageInclusive()
+	xidx := make([]int, 0, p.window[0]*p.window[1])
+	for i := 0; i < n*c; i++ {
+		for y := 0; y < oh; y++ {
+			for x := 0; x < ow; x++ {
+				xidx = xidx[:0]
+				hs := y*int(p.stride[0]) - int(p.pad[0])
+				ws := x*int(p.stride[1]) - int(p.pad[1])
+				for wy := hs; wy < hs+int(p.window[0]); wy++ {
+					for wx := ws; wx < ws+int(p.window[1]); wx++ {
+						if wy >= 0 && wy < ih && wx >= 0 && wx < iw {
+							xidx = append(xidx, (i*ih+wy)*iw+wx)
+						}
+					}
+				}
+				count := len(xidx)
+				if inclusive {
+					count = int(p.window[0] * p.window[1])
+				}
+				f((i*oh+y)*ow+x, xidx, count)
+			}
+		}
+	}
+}
+
+func (p *poolingparams) check(xshape, yshape []int32, comment string) error {
+	if len(xshape) != 4 || len(yshape) != 4 || xshape[0] != yshape[0] || xshape[1] != yshape[1] {
+		return statusBadParm.error(comment + ": x and y must be 4d tensors with the same N and C")
+	}
+	return nil
+}
+
+//PoolingForward - dobackwards, wspace and wspaceSIB are ignored by the cpu backend.
+func (cb *cpuBackend) PoolingForward(p *PoolingD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	dobackwards bool, wspace cutil.Mem, wspaceSIB uint) error {
+	defer cb.timed(time.Now())
+	const comment = "(*Pooling)Forward()"
+	pp, err := p.params()
+	if err != nil {
+		return err
+	}
+	xv, err := viewof(xD, x, comment)
+	if err != nil {
+		return err
+	}
+	yv, err := viewof(yD, y, comment)
+	if err != nil {
+		return err
+	}
+	if err = pp.check(xv.shape, yv.shape, comment); err != nil {
+		return err
+	}
+	xs := xv.load()
+	ys := make([]float64, yv.volume())
+	var flg PoolingMode
+	max := pp.mode == flg.Max()
+	pp.poolwalk(xv.shape, yv.shape, func(yidx int, xidx []int, count int) {
+		if len(xidx) == 0 {
+			return
+		}
+		if max {
+			ys[yidx] = math.Inf(-1)
+			for _, i := range xidx {
+				ys[yidx] = math.Max(ys[yidx], xs[i])
+			}
+			return
+		}
+		for _, i := range xidx {
+			ys[yidx] += xs[i]
+		}
+		ys[yidx] /= float64(count)
+	})
+	yv.store(ys, alpha, beta)
+	return nil
+}
+
+//PoolingBackward - For max pooling the cpu backend routes dy to the first maximum of each window in x.
+func (cb *cpuBackend) PoolingBackward(p *PoolingD, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem) error {
+	defer cb.timed(time.Now())
+	const comment = "(*PoolingD)Backward()"
+	pp, err := p.params()
+	if err != nil {
+		return err
+	}
+	dyv, err := viewof(dyD, dy, comment)
+	if err != nil {
+		return err
+	}
+	xv, err := viewof(xD, x, comment)
+	if err != nil {
+		return err
+	}
+	dxv, err := viewof(dxD, dx, comment)
+	if err != nil {
+		return err
+	}
+	if err = pp.check(xv.shape, dyv.shape, comment); err != nil {
+		return err
+	}
+	if !comparedims(xv.shape, dxv.shape) {
+		return statusBadParm.error(comment + ": x and dx shapes differ")
+	}
+	xs, dys := xv.load(), dyv.load()
+	dxs := make([]float64, len(xs))
+	var flg PoolingMode
+	max := pp.mode == flg.Max()
+	pp.poolwalk(xv.shape, dyv.shape, func(yidx int, xidx []int, count int) {
+		if len(xidx) == 0 {
+			return
+		}
+		if max {
+			best := xidx[0]
+			for _, i := range xidx[1:] {
+				if xs[i] > xs[best] {
+					best = i
+				}
+			}
+			dxs[best] += dys[yidx]
+			return
+		}
+		for _, i := range xidx {
+			dxs[i] += dys[yidx] / float64(count)
+		}
+	})
+	dxv.store(dxs, alpha, beta)
+	return nil
+}
@@ -0,0 +1,149 @@
+package miopen
+
+import (
+	"math"
+	"time"
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//GetReductionIndicesSize returns the size of the indices of Min, Max and AMax, one per element of c.
+func (cb *cpuBackend) GetReductionIndicesSize(rt *ReduceTensorD, aD, cD *TensorD) (indicesSIB uint, err error) {
+	op, _, _, indices, itype, err := rt.Get()
+	if err != nil {
+		return 0, err
+	}
+	var flg ReduceTensorIndices
+	if indices != flg.Flattened() || !op.indexed() {
+		return 0, nil
+	}
+	_, shape, _, err := cD.Get()
+	if err != nil {
+		return 0, err
+	}
+	return uint(findvolume(shape)) * itype.size(), nil
+}
+
+//GetReductionWorkspaceSize is 0, the cpu backend doesn't need a workspace
+func (cb *cpuBackend) GetReductionWorkspaceSize(rt *ReduceTensorD, aD, cD *TensorD) (wspaceSIB uint, err error) {
+	return 0, nil
+}
+
+//ReduceTensor - c = alpha*reduce(a) + beta*c, reducing the dims of a where c is 1
+func (cb *cpuBackend) ReduceTensor(rt *ReduceTensorD,
+	indices cutil.Mem, indicesSIB uint,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha float64, aD *TensorD, a cutil.Mem,
+	beta float64, cD *TensorD, c cutil.Mem) error {
+	defer cb.timed(time.Now())
+	const comment = "(r *ReduceTensorD)Reduce()"
+	op, _, nan, idx, itype, err := rt.Get()
+	if err != nil {
+		return err
+	}
+	av, err := viewof(aD, a, comment)
+	if err != nil {
+		return err
+	}
+	cv, err := viewof(cD, c, comment)
+	if err != nil {
+		return err
+	}
+	cmap, ok := broadcastmap(av.shape, cv.shape)
+	if !ok {
+		return statusBadParm.error(comment + ": c must have the shape of a with the reduced dims set to 1")
+	}
+	var (
+		flg    ReduceTensorOp
+		nanflg NanPropagation
+		idxflg ReduceTensorIndices
+	)
+	withindices := idx == idxflg.Flattened() && op.indexed()
+	if withindices && (indices == nil || indicesSIB < uint(cv.volume())*itype.size()) {
+		return statusBadParm.error(comment + ": indices are too small")
+	}
+	//rstride gives the flattened index of an element within the reduced dims
+	rshape := make([]int32, len(av.shape))
+	for i := range rshape {
+		rshape[i] = 1
+		if cv.shape[i] == 1 {
+			rshape[i] = av.shape[i]
+		}
+	}
+	rstride := stridecalc(rshape)
+	out := make([]float64, cv.volume())
+	picked := make([]int, cv.volume())
+	seen := make([]bool, cv.volume())
+	n := float64(av.volume() / cv.volume())
+	vals := av.load()
+	i := 0
+	forEachIndex(av.shape, func(ai []int32) {
+		v, o, pos := vals[i], cmap[i], 0
+		i++
+		for d := range ai {
+			if cv.shape[d] == 1 {
+				pos += int(ai[d] * rstride[d])
+			}
+		}
+		switch op {
+		case flg.Add(), flg.Avg():
+			out[o] += v
+		case flg.Mul():
+			if !seen[o] {
+				out[o] = 1
+			}
+			out[o] *= v
+		case flg.Norm1():
+			out[o] += math.Abs(v)
+		case flg.Norm2():
+			out[o] += v * v
+		case flg.Min(), flg.Max(), flg.AMax():
+			if op == flg.AMax() {
+				v = math.Abs(v)
+			}
+			//NaNs are skipped unless they are propagated, and a propagated NaN is kept
+			switch {
+			case math.IsNaN(out[o]):
+			case math.IsNaN(v):
+				if nan == nanflg.Propagate() {
+					out[o], picked[o], seen[o] = v, pos, true
+				}
+			case !seen[o] || (op == flg.Min() && v < out[o]) || (op != flg.Min() && v > out[o]):
+				out[o], picked[o], seen[o] = v, pos, true
+			}
+			return
+		}
+		seen[o] = true
+	})
+	for o := range out {
+		switch op {
+		case flg.Avg():
+			out[o] /= n
+		case flg.Norm2():
+			out[o] = math.Sqrt(out[o])
+		}
+	}
+	cv.store(out, alpha, beta)
+	if withindices {
+		writeindices(indices.Ptr(), itype, picked)
+	}
+	return nil
+}
+
+//writeindices writes picked to p as itype
+func writeindices(p unsafe.Pointer, itype IndicesType, picked []int) {
+	var flg IndicesType
+	for i, v := range picked {
+		switch itype {
+		case flg.Int64():
+			*(*int64)(unsafe.Pointer(uintptr(p) + uintptr(i)*8)) = int64(v)
+		case flg.Int16():
+			*(*int16)(unsafe.Pointer(uintptr(p) + uintptr(i)*2)) = int16(v)
+		case flg.Int8():
+			*(*int8)(unsafe.Pointer(uintptr(p) + uintptr(i))) = int8(v)
+		default:
+			*(*int32)(unsafe.Pointer(uintptr(p) + uintptr(i)*4)) = int32(v)
+		}
+	}
+}
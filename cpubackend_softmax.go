@@ -0,0 +1,101 @@
+package miopen
+
+import (
+	"math"
+	"time"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//channelsplit returns the batch, channel and spatial sizes of a packed N,C,... shape
+func channelsplit(shape []int32) (n, c, s int) {
+	n, c, s = int(shape[0]), 1, 1
+	if len(shape) > 1 {
+		c = int(shape[1])
+		s = int(findvolume(shape[2:]))
+	}
+	return n, c, s
+}
+
+//SoftmaxForward - y = alpha*softmax(x) + beta*y
+func (cb *cpuBackend) SoftmaxForward(alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	defer cb.timed(time.Now())
+	xv, err := viewof(xD, x, "(s *SoftMaxD)Forward()")
+	if err != nil {
+		return err
+	}
+	yv, err := viewof(yD, y, "(s *SoftMaxD)Forward()")
+	if err != nil {
+		return err
+	}
+	if !comparedims(xv.shape, yv.shape) {
+		return statusBadParm.error("(s *SoftMaxD)Forward(): x and y shapes differ")
+	}
+	vals := xv.load()
+	n, c, sp := channelsplit(xv.shape)
+	for i := 0; i < n; i++ {
+		for j := 0; j < sp; j++ {
+			max := math.Inf(-1)
+			for k := 0; k < c; k++ {
+				max = math.Max(max, vals[(i*c+k)*sp+j])
+			}
+			var sum float64
+			for k := 0; k < c; k++ {
+				idx := (i*c+k)*sp + j
+				vals[idx] = math.Exp(vals[idx] - max)
+				sum += vals[idx]
+			}
+			for k := 0; k < c; k++ {
+				vals[(i*c+k)*sp+j] /= sum
+			}
+		}
+	}
+	yv.store(vals, alpha, beta)
+	return nil
+}
+
+//SoftmaxBackward - dx = alpha*y*(dy - sum(dy*y)) + beta*dx
+func (cb *cpuBackend) SoftmaxBackward(alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	defer cb.timed(time.Now())
+	const comment = "(s *SoftMaxD)Backward()"
+	yv, err := viewof(yD, y, comment)
+	if err != nil {
+		return err
+	}
+	dyv, err := viewof(dyD, dy, comment)
+	if err != nil {
+		return err
+	}
+	dxv, err := viewof(dxD, dx, comment)
+	if err != nil {
+		return err
+	}
+	if !comparedims(yv.shape, dyv.shape, dxv.shape) {
+		return statusBadParm.error(comment + ": tensor shapes differ")
+	}
+	ys, dys := yv.load(), dyv.load()
+	dxs := make([]float64, len(ys))
+	n, c, sp := channelsplit(yv.shape)
+	for i := 0; i < n; i++ {
+		for j := 0; j < sp; j++ {
+			var dot float64
+			for k := 0; k < c; k++ {
+				idx := (i*c+k)*sp + j
+				dot += ys[idx] * dys[idx]
+			}
+			for k := 0; k < c; k++ {
+				idx := (i*c+k)*sp + j
+				dxs[idx] = ys[idx] * (dys[idx] - dot)
+			}
+		}
+	}
+	dxv.store(dxs, alpha, beta)
+	return nil
+}
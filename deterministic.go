@@ -0,0 +1,88 @@
+package miopen
+
+//SetDeterministic turns deterministic mode on or off.  It is off by default.
+//
+//In deterministic mode the backward data and backward weights convolutions only use algorithms that give the
+//same results on every run:
+//
+//	Find functions		the algorithms that aren't deterministic are dropped from the results
+//	Get*Solutions		the solutions that aren't deterministic are dropped
+//	BackwardData		an algorithm that isn't deterministic returns ErrBadParm
+//	BackwardWeights		an algorithm that isn't deterministic returns ErrBadParm
+//
+//If MIOpen has Feature.DeterministicConvolution the backward calls set the MIOPEN_CONVOLUTION_ATTRIB_DETERMINISTIC
+//attribute of the convolution descriptor to the mode of h, and MIOpen picks the kernels itself.  Otherwise the
+//fallback is a heuristic: the implicit GEMM algorithms of the backward passes accumulate with atomics, so
+//they are dropped, and every other algorithm is assumed to be deterministic.  The heuristic can miss a
+//kernel that isn't deterministic.
+//
+//Forward convolutions and the pooling, LRN, activation, softmax and batch normalization backward passes are
+//always deterministic and aren't changed.  Without the attribute the Immediate functions can't tell the
+//algorithm from a solution ID, so only pass them IDs from Get*Solutions.
+func (h *Handle) SetDeterministic(on bool) {
+	h.deterministic = on
+}
+
+//Deterministic returns true if deterministic mode is on
+func (h *Handle) Deterministic() bool {
+	return h.deterministic
+}
+
+//nondeterministic returns true if algo of a backward convolution accumulates with atomics
+func nondeterministic(algo int32) bool {
+	return algo == implicitgemm
+}
+
+//blocklist returns true if h is in deterministic mode and MIOpen doesn't have the deterministic attribute,
+//then the algorithms that nondeterministic returns true for are dropped.
+func (h *Handle) blocklist() bool {
+	return h != nil && h.deterministic && Supported(new(Feature).DeterministicConvolution()) != nil
+}
+
+//setdeterministic sets the deterministic attribute of c to the mode of h if MIOpen has it.  It is set on
+//every backward call since c can be used with handles in either mode.
+func (k *check) setdeterministic(h *Handle, c *ConvolutionD) {
+	if k.err != nil || h == nil || Supported(new(Feature).DeterministicConvolution()) != nil {
+		return
+	}
+	k.err = c.setdeterministic(h.deterministic)
+}
+
+//deterministicperfs drops the perfs of backward convolutions that aren't deterministic if h uses the
+//blocklist.
+func (h *Handle) deterministicperfs(op string, perfs []traceperf) []traceperf {
+	if !h.blocklist() || op == "forward" {
+		return perfs
+	}
+	kept := make([]traceperf, 0, len(perfs))
+	for _, p := range perfs {
+		if !nondeterministic(p.Algo) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+//deterministicsolutions drops the solutions of backward convolutions that aren't deterministic if h uses
+//the blocklist.
+func (h *Handle) deterministicsolutions(solutions []ConvSolution) []ConvSolution {
+	if !h.blocklist() {
+		return solutions
+	}
+	kept := make([]ConvSolution, 0, len(solutions))
+	for _, s := range solutions {
+		if !nondeterministic(int32(s.Algorithm)) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+//deterministic sets the deterministic attribute of c, or fails if h uses the blocklist and algo of a backward
+//convolution isn't deterministic.
+func (k *check) deterministic(h *Handle, c *ConvolutionD, algo int32) {
+	k.setdeterministic(h, c)
+	if h.blocklist() && nondeterministic(algo) {
+		k.fail("algorithm %d accumulates with atomics and isn't deterministic, the handle is in deterministic mode", algo)
+	}
+}
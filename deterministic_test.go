@@ -0,0 +1,42 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"errors"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestDeterministic(t *testing.T) {
+	h := miopen.CreateHandle()
+	h.SetDeterministic(true)
+	c, xD, wD, yD := convfixture(t)
+	x, dy, dw := make(floats, 9), make(floats, 4), make(floats, 4)
+	var algo miopen.ConvBwdWeightsAlgorithm
+	implicitgemm := algo.ImplicitGEMM()
+	err := c.BackwardWeights(h, 1, yD, dy, xD, x, implicitgemm, 0, wD, dw, nil, 0)
+	if !errors.Is(err, miopen.ErrBadParm) {
+		t.Fatal("expected implicit GEMM to be rejected in deterministic mode, got", err)
+	}
+	var direct miopen.ConvBwdWeightsAlgorithm
+	if err = c.BackwardWeights(h, 1, yD, dy, xD, x, direct.Direct(), 0, wD, dw, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	counter := &findcounter{Backend: miopen.NewCPUBackend()}
+	if h, err = miopen.NewHandle(miopen.WithBackend(counter)); err != nil {
+		t.Fatal(err)
+	}
+	y := make(floats, 4)
+	for _, on := range []bool{false, true, true} {
+		h.SetDeterministic(on)
+		if err = c.ForwardAuto(h, 1, xD, x, wD, dw, 0, yD, y); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if counter.n != 2 {
+		t.Fatal("expected the algorithm picked outside of deterministic mode to be picked again in it, got", counter.n, "searches")
+	}
+}
@@ -0,0 +1,394 @@
+package miopen
+
+import (
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//The element-wise operations in this file are methods of the descriptor of their output, like SetAll and Scale.
+//Their inputs are broadcast to the output like NumPy does it: shapes are lined up on their last dim, missing
+//leading dims are 1, and every dim must be 1 or equal to the dim of the output.  They are built out of
+//OpTensor, SetTensor, ScaleTensor, LogTensor and activations, so they run on the handle's backend and show up
+//in a trace as those calls.  Temporary tensors are taken from h.Workspace().
+
+//operand is a tensor of an element-wise operation, with its descriptor given the rank of the output
+type operand struct {
+	d     *TensorD
+	m     cutil.Mem
+	shape []int32
+}
+
+//offsetmem points into a workspace holding more than one temporary
+type offsetmem struct {
+	p unsafe.Pointer
+}
+
+func (m *offsetmem) Ptr() unsafe.Pointer   { return m.p }
+func (m *offsetmem) DPtr() *unsafe.Pointer { return &m.p }
+
+//pointwise runs an element-wise operation that writes to y
+type pointwise struct {
+	h     *Handle
+	k     check
+	y     operand
+	yt    tensorinfo
+	descs []*TensorD //made for the operation and destroyed by done
+}
+
+func newpointwise(op string, h *Handle, yD *TensorD, y cutil.Mem) *pointwise {
+	p := &pointwise{h: h, k: check{op: op}}
+	p.yt = p.k.tensor("t", yD, y)
+	p.y = operand{d: yD, m: y, shape: p.yt.shape}
+	return p
+}
+
+//input checks that x can be broadcast to the output and returns it with the rank of the output.
+func (p *pointwise) input(name string, xD *TensorD, x cutil.Mem) operand {
+	xt := p.k.tensor(name, xD, x)
+	p.k.samedtype(p.yt, xt)
+	if p.k.err != nil {
+		return operand{}
+	}
+	rank := len(p.y.shape)
+	if len(xt.shape) > rank {
+		p.k.fail("%s %v has a higher rank than t %v", name, xt.shape, p.y.shape)
+		return operand{}
+	}
+	if len(xt.shape) == rank {
+		p.k.broadcast(xt, p.yt)
+		return operand{d: xD, m: x, shape: xt.shape}
+	}
+	_, _, stride, err := xD.Get()
+	if err != nil {
+		p.k.err = err
+		return operand{}
+	}
+	shape, astride := ones(rank), make([]int32, rank)
+	lead := rank - len(xt.shape)
+	copy(shape[lead:], xt.shape)
+	copy(astride[lead:], stride)
+	for i := lead - 1; i >= 0; i-- {
+		astride[i] = astride[i+1] * shape[i+1]
+	}
+	if _, ok := broadcastmap(p.y.shape, shape); !ok {
+		p.k.fail("%s %v can't be broadcast to t %v", name, xt.shape, p.y.shape)
+		return operand{}
+	}
+	d, err := p.descriptor(shape, astride)
+	if err != nil {
+		p.k.err = err
+	}
+	return operand{d: d, m: x, shape: shape}
+}
+
+func (p *pointwise) descriptor(shape, stride []int32) (*TensorD, error) {
+	d, err := CreateTensorDescriptor()
+	if err != nil {
+		return nil, err
+	}
+	p.descs = append(p.descs, d)
+	return d, d.Set(p.yt.dtype, shape, stride)
+}
+
+//temps returns packed tensors with shapes that share one buffer from the handle's workspace.
+func (p *pointwise) temps(shapes ...[]int32) ([]operand, error) {
+	const align = 256
+	ts := make([]operand, len(shapes))
+	offs := make([]uintptr, len(shapes))
+	var sib uint
+	for i, shape := range shapes {
+		d, err := p.descriptor(shape, nil)
+		if err != nil {
+			return nil, err
+		}
+		n, err := d.GetSIB()
+		if err != nil {
+			return nil, err
+		}
+		ts[i], offs[i] = operand{d: d, shape: shape}, uintptr(sib)
+		sib += (n + align - 1) / align * align
+	}
+	ws, err := p.h.Workspace().Get(sib)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ts {
+		ts[i].m = &offsetmem{p: unsafe.Pointer(uintptr(ws.Ptr()) + offs[i])}
+	}
+	return ts, nil
+}
+
+//constant fills the temporary c with v
+func (p *pointwise) constant(c operand, v float64) error {
+	return p.h.b.SetTensor(c.d, c.m, v)
+}
+
+//broadcast sets c to alpha*x
+func (p *pointwise) broadcast(alpha float64, x, c operand) error {
+	if comparedims(x.shape, c.shape) {
+		return p.h.b.TransformTensor(alpha, x.d, x.m, 0, c.d, c.m)
+	}
+	//c is zeroed first, 0 times whatever was in it could be NaN
+	if err := p.h.b.SetTensor(c.d, c.m, 0); err != nil {
+		return err
+	}
+	var op OpTensorOp
+	return p.h.b.OpTensor(op.Add(), 0, c.d, c.m, alpha, x.d, x.m, 0, c.d, c.m)
+}
+
+//binary runs c = op(alpha1*a, alpha2*b).  MIOpen only broadcasts B, so the operands are swapped if only b has
+//the shape of c (all four ops commute), and a is copied to c first if neither does.
+func (p *pointwise) binary(op OpTensorOp, alpha1 float64, a operand, alpha2 float64, b, c operand) error {
+	if !comparedims(a.shape, c.shape) && comparedims(b.shape, c.shape) {
+		alpha1, a, alpha2, b = alpha2, b, alpha1, a
+	}
+	if !comparedims(a.shape, c.shape) {
+		if err := p.broadcast(alpha1, a, c); err != nil {
+			return err
+		}
+		alpha1, a = 1, c
+	}
+	return p.h.b.OpTensor(op, alpha1, a.d, a.m, alpha2, b.d, b.m, 0, c.d, c.m)
+}
+
+//activation runs y = f(x) with f set by mode, alpha, beta and gamma.
+func (p *pointwise) activation(mode ActivationMode, alpha, beta, gamma float64, x, y operand) error {
+	if !comparedims(x.shape, y.shape) {
+		if err := p.broadcast(1, x, y); err != nil {
+			return err
+		}
+		x = y
+	}
+	a, err := CreateActivationDescriptor()
+	if err != nil {
+		return err
+	}
+	defer a.Destroy()
+	if err = a.Set(mode, alpha, beta, gamma); err != nil {
+		return err
+	}
+	return p.h.b.ActivationForward(a, 1, x.d, x.m, 0, y.d, y.m)
+}
+
+//done destroys the descriptors made for the operation and adds the descriptors passed to err.
+func (p *pointwise) done(err error, namesanddescs ...interface{}) error {
+	for _, d := range p.descs {
+		d.Destroy()
+	}
+	return withdesc(err, namesanddescs...)
+}
+
+func ones(rank int) []int32 {
+	s := make([]int32, rank)
+	for i := range s {
+		s[i] = 1
+	}
+	return s
+}
+
+//Add - t = a + b, with a and b broadcast to t.
+func (t *TensorD) Add(h *Handle, tmem cutil.Mem, aD *TensorD, a cutil.Mem, bD *TensorD, b cutil.Mem) error {
+	var op OpTensorOp
+	return t.optensor("(t *TensorD)Add()", op.Add(), 1, h, tmem, aD, a, bD, b)
+}
+
+//Sub - t = a - b, with a and b broadcast to t.
+func (t *TensorD) Sub(h *Handle, tmem cutil.Mem, aD *TensorD, a cutil.Mem, bD *TensorD, b cutil.Mem) error {
+	var op OpTensorOp
+	return t.optensor("(t *TensorD)Sub()", op.Add(), -1, h, tmem, aD, a, bD, b)
+}
+
+//Mul - t = a * b, with a and b broadcast to t.
+func (t *TensorD) Mul(h *Handle, tmem cutil.Mem, aD *TensorD, a cutil.Mem, bD *TensorD, b cutil.Mem) error {
+	var op OpTensorOp
+	return t.optensor("(t *TensorD)Mul()", op.Mul(), 1, h, tmem, aD, a, bD, b)
+}
+
+func (t *TensorD) optensor(name string, op OpTensorOp, alpha2 float64, h *Handle, tmem cutil.Mem, aD *TensorD, a cutil.Mem, bD *TensorD, b cutil.Mem) error {
+	p := newpointwise(name, h, t, tmem)
+	ao, bo := p.input("aD", aD, a), p.input("bD", bD, b)
+	err := p.k.err
+	if err == nil {
+		err = p.binary(op, 1, ao, alpha2, bo, p.y)
+	}
+	return p.done(err, "t", t, "aD", aD, "bD", bD)
+}
+
+//Div - t = a / b, with a and b broadcast to t.  It is a times the reciprocal of b, what dividing by 0 gives
+//depends on the backend.
+func (t *TensorD) Div(h *Handle, tmem cutil.Mem, aD *TensorD, a cutil.Mem, bD *TensorD, b cutil.Mem) error {
+	p := newpointwise("(t *TensorD)Div()", h, t, tmem)
+	ao, bo := p.input("aD", aD, a), p.input("bD", bD, b)
+	err := p.k.err
+	if err == nil {
+		err = p.div(ao, bo)
+	}
+	return p.done(err, "t", t, "aD", aD, "bD", bD)
+}
+
+func (p *pointwise) div(a, b operand) error {
+	tmp, err := p.temps(b.shape)
+	if err != nil {
+		return err
+	}
+	//the power activation is (alpha + beta*x)^gamma
+	var mode ActivationMode
+	if err = p.activation(mode.Power(), 0, 1, -1, b, tmp[0]); err != nil {
+		return err
+	}
+	var op OpTensorOp
+	return p.binary(op.Mul(), 1, a, 1, tmp[0], p.y)
+}
+
+//Pow - t = x^e, with x broadcast to t.  x must be positive unless e is a whole number.
+func (t *TensorD) Pow(h *Handle, tmem cutil.Mem, xD *TensorD, x cutil.Mem, e float64) error {
+	var mode ActivationMode
+	return t.activation("(t *TensorD)Pow()", mode.Power(), 0, 1, e, h, tmem, xD, x)
+}
+
+//Sqrt - t = sqrt(x), with x broadcast to t.
+func (t *TensorD) Sqrt(h *Handle, tmem cutil.Mem, xD *TensorD, x cutil.Mem) error {
+	var mode ActivationMode
+	return t.activation("(t *TensorD)Sqrt()", mode.Power(), 0, 1, 0.5, h, tmem, xD, x)
+}
+
+//Abs - t = |x|, with x broadcast to t.
+func (t *TensorD) Abs(h *Handle, tmem cutil.Mem, xD *TensorD, x cutil.Mem) error {
+	var mode ActivationMode
+	return t.activation("(t *TensorD)Abs()", mode.Abs(), 0, 0, 0, h, tmem, xD, x)
+}
+
+func (t *TensorD) activation(name string, mode ActivationMode, alpha, beta, gamma float64, h *Handle, tmem cutil.Mem, xD *TensorD, x cutil.Mem) error {
+	p := newpointwise(name, h, t, tmem)
+	xo := p.input("xD", xD, x)
+	err := p.k.err
+	if err == nil {
+		err = p.activation(mode, alpha, beta, gamma, xo, p.y)
+	}
+	return p.done(err, "t", t, "xD", xD)
+}
+
+//Clamp - t = min(max(x, lo), hi), with x broadcast to t.
+func (t *TensorD) Clamp(h *Handle, tmem cutil.Mem, xD *TensorD, x cutil.Mem, lo, hi float64) error {
+	p := newpointwise("(t *TensorD)Clamp()", h, t, tmem)
+	xo := p.input("xD", xD, x)
+	if lo > hi {
+		p.k.fail("lo %v is greater than hi %v", lo, hi)
+	}
+	err := p.k.err
+	if err == nil {
+		err = p.clamp(xo, lo, hi)
+	}
+	return p.done(err, "t", t, "xD", xD)
+}
+
+func (p *pointwise) clamp(x operand, lo, hi float64) error {
+	c, err := p.temps(ones(len(p.y.shape)), ones(len(p.y.shape)))
+	if err != nil {
+		return err
+	}
+	if err = p.constant(c[0], lo); err != nil {
+		return err
+	}
+	if err = p.constant(c[1], hi); err != nil {
+		return err
+	}
+	var op OpTensorOp
+	if err = p.binary(op.Max(), 1, x, 1, c[0], p.y); err != nil {
+		return err
+	}
+	return p.binary(op.Min(), 1, p.y, 1, c[1], p.y)
+}
+
+//Exp - t = e^x, with x broadcast to t.
+//
+//There is no exp activation.  e^x is computed as logistic(x)/logistic(-x): for negative x the numerator is
+//close to e^x and the denominator close to 1, and the other way round for positive x, so the result keeps
+//its relative accuracy in both tails.  It takes a few more calls than the other operations.
+func (t *TensorD) Exp(h *Handle, tmem cutil.Mem, xD *TensorD, x cutil.Mem) error {
+	p := newpointwise("(t *TensorD)Exp()", h, t, tmem)
+	xo := p.input("xD", xD, x)
+	err := p.k.err
+	if err == nil {
+		err = p.exp(xo)
+	}
+	return p.done(err, "t", t, "xD", xD)
+}
+
+func (p *pointwise) exp(x operand) error {
+	tmp, err := p.temps(p.y.shape)
+	if err != nil {
+		return err
+	}
+	den := tmp[0]
+	var (
+		mode ActivationMode
+		op   OpTensorOp
+	)
+	steps := []func() error{
+		//den = 1/logistic(-x), it is read from x before t is written in case they are the same memory
+		func() error { return p.broadcast(-1, x, den) },
+		func() error { return p.activation(mode.Logistic(), 0, 0, 0, den, den) },
+		func() error { return p.activation(mode.Power(), 0, 1, -1, den, den) },
+		//t = logistic(x)*den
+		func() error { return p.activation(mode.Logistic(), 0, 0, 0, x, p.y) },
+		func() error { return p.binary(op.Mul(), 1, p.y, 1, den, p.y) },
+	}
+	for _, step := range steps {
+		if err = step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Log - t = ln(x), with x broadcast to t.
+//
+//MIOpen has nothing log can be built out of, so the rocm backend syncs the handle's stream and copies t to the
+//host, and back after taking the log.  It is slow in the rocm build, keep it out of the inner loop.
+func (t *TensorD) Log(h *Handle, tmem cutil.Mem, xD *TensorD, x cutil.Mem) error {
+	p := newpointwise("(t *TensorD)Log()", h, t, tmem)
+	xo := p.input("xD", xD, x)
+	err := p.k.err
+	if err == nil {
+		err = p.log(xo)
+	}
+	return p.done(err, "t", t, "xD", xD)
+}
+
+func (p *pointwise) log(x operand) error {
+	if err := p.broadcast(1, x, p.y); err != nil {
+		return err
+	}
+	return p.h.b.LogTensor(p.y.d, p.y.m)
+}
+
+//Where - t = a where cond is 1 and b where it is 0, with cond, a and b broadcast to t.
+//
+//cond must only hold 0s and 1s, and a and b must be finite: it is computed as b + cond*(a-b).  tmem can't be
+//the memory of b.
+func (t *TensorD) Where(h *Handle, tmem cutil.Mem, condD *TensorD, cond cutil.Mem, aD *TensorD, a cutil.Mem, bD *TensorD, b cutil.Mem) error {
+	p := newpointwise("(t *TensorD)Where()", h, t, tmem)
+	co, ao, bo := p.input("condD", condD, cond), p.input("aD", aD, a), p.input("bD", bD, b)
+	err := p.k.err
+	if err == nil {
+		err = p.where(co, ao, bo)
+	}
+	return p.done(err, "t", t, "condD", condD, "aD", aD, "bD", bD)
+}
+
+func (p *pointwise) where(cond, a, b operand) error {
+	tmp, err := p.temps(p.y.shape)
+	if err != nil {
+		return err
+	}
+	var op OpTensorOp
+	if err = p.binary(op.Add(), 1, a, -1, b, tmp[0]); err != nil {
+		return err
+	}
+	if err = p.binary(op.Mul(), 1, cond, 1, tmp[0], p.y); err != nil {
+		return err
+	}
+	return p.binary(op.Add(), 1, p.y, 1, b, p.y)
+}
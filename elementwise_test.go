@@ -0,0 +1,88 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestElementwise(t *testing.T) {
+	h := miopen.CreateHandle()
+	fromslice := func(dims []int32, vals ...float32) *miopen.Tensor {
+		x, err := miopen.FromFloat32Slice(nil, dims, vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return x
+	}
+	//b and the row of cond have a lower rank than y, they are lined up on the last dim
+	a := fromslice([]int32{2, 3}, 1, 4, 9, -1, -4, 0.25)
+	b := fromslice([]int32{3}, 2, -1, 0.5)
+	cond := fromslice([]int32{2, 1}, 1, 0)
+	tails := fromslice([]int32{2, 3}, -10, -20, -80, 10, 20, 80)
+	y, err := miopen.NewTensor(nil, a.DataType(), []int32{2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	yD := y.Descriptor()
+	for _, tc := range []struct {
+		name string
+		run  func() error
+		want []float64
+	}{
+		{"Add", func() error { return yD.Add(h, y, a.Descriptor(), a, b.Descriptor(), b) }, []float64{3, 3, 9.5, 1, -5, 0.75}},
+		{"Sub", func() error { return yD.Sub(h, y, b.Descriptor(), b, a.Descriptor(), a) }, []float64{1, -5, -8.5, 3, 3, 0.25}},
+		{"Mul", func() error { return yD.Mul(h, y, a.Descriptor(), a, b.Descriptor(), b) }, []float64{2, -4, 4.5, -2, 4, 0.125}},
+		{"Div", func() error { return yD.Div(h, y, a.Descriptor(), a, b.Descriptor(), b) }, []float64{0.5, -4, 18, -0.5, 4, 0.5}},
+		{"Pow", func() error { return yD.Pow(h, y, b.Descriptor(), b, 2) }, []float64{4, 1, 0.25, 4, 1, 0.25}},
+		{"Sqrt", func() error { return yD.Sqrt(h, y, b.Descriptor(), b) }, []float64{math.Sqrt2, math.NaN(), math.Sqrt(0.5), math.Sqrt2, math.NaN(), math.Sqrt(0.5)}},
+		{"Exp", func() error { return yD.Exp(h, y, a.Descriptor(), a) }, []float64{math.E, math.Exp(4), math.Exp(9), math.Exp(-1), math.Exp(-4), math.Exp(0.25)}},
+		{"Exp tails", func() error { return yD.Exp(h, y, tails.Descriptor(), tails) }, []float64{math.Exp(-10), math.Exp(-20), math.Exp(-80), math.Exp(10), math.Exp(20), math.Exp(80)}},
+		{"Log", func() error { return yD.Log(h, y, b.Descriptor(), b) }, []float64{math.Ln2, math.NaN(), -math.Ln2, math.Ln2, math.NaN(), -math.Ln2}},
+		{"Abs", func() error { return yD.Abs(h, y, a.Descriptor(), a) }, []float64{1, 4, 9, 1, 4, 0.25}},
+		{"Clamp", func() error { return yD.Clamp(h, y, a.Descriptor(), a, -2, 4) }, []float64{1, 4, 4, -1, -2, 0.25}},
+		{"Where", func() error {
+			return yD.Where(h, y, cond.Descriptor(), cond, a.Descriptor(), a, b.Descriptor(), b)
+		}, []float64{1, 4, 9, 2, -1, 0.5}},
+	} {
+		if err = tc.run(); err != nil {
+			t.Fatal(tc.name, err)
+		}
+		got, err := y.ToFloat32Slice()
+		if err != nil {
+			t.Fatal(err)
+		}
+		//the error is relative, so small results like e^-20 are checked as closely as large ones
+		for i, w := range tc.want {
+			if math.IsNaN(w) != math.IsNaN(float64(got[i])) || math.Abs(float64(got[i])-w) > 1e-5*math.Abs(w) {
+				t.Error(tc.name, "unexpected output", got, "want", tc.want)
+				break
+			}
+		}
+	}
+	c := fromslice([]int32{2}, 1, 2)
+	if err = yD.Add(h, y, a.Descriptor(), a, c.Descriptor(), c); !errors.Is(err, miopen.ErrBadParm) {
+		t.Error("expected [2] to not broadcast to [2 3], got", err)
+	}
+	if err = yD.Clamp(h, y, a.Descriptor(), a, 1, 0); !errors.Is(err, miopen.ErrBadParm) {
+		t.Error("expected lo > hi to be rejected, got", err)
+	}
+	rec := h.Record()
+	if err = yD.Log(h, y, a.Descriptor(), a); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = rec.WriteTrace(&buf); err != nil {
+		t.Fatal(err)
+	}
+	trace, err := miopen.ReadTrace(&buf)
+	if err != nil || len(trace) == 0 || trace[len(trace)-1].Func != "LogTensor" {
+		t.Error("expected Log to be traced as LogTensor, got", trace, err)
+	}
+}
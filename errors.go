@@ -0,0 +1,166 @@
+package miopen
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Error is returned when MIOpen, or a backend standing in for it, returns a Status other than success.
+//
+//Use errors.Is with the Err sentinels to check the status, or errors.As to get the operation and
+//descriptors that made it.
+//
+//	if errors.Is(err, miopen.ErrNotImplemented) {
+//		//retry with a different algorithm
+//	}
+type Error struct {
+	Status Status `json:"status"`
+	Op     string `json:"op"`             //Op is the go operation (or the comment passed with the status)
+	Desc   string `json:"desc,omitempty"` //Desc summarizes the descriptors passed to Op when they are known
+}
+
+//Sentinel errors that match any *Error with the same Status through errors.Is
+var (
+	ErrNotInitialized = &Error{Status: statusNotInitialized}
+	ErrInvalidValue   = &Error{Status: statusInvalidValue}
+	ErrBadParm        = &Error{Status: statusBadParm}
+	ErrAllocFailed    = &Error{Status: statusAllocFailed}
+	ErrInternalError  = &Error{Status: statusInternalError}
+	ErrNotImplemented = &Error{Status: statusNotImplemented}
+	ErrUnknownError   = &Error{Status: statusUnknownError}
+	ErrUnsupportedOp  = &Error{Status: statusUnsupportedOp}
+)
+
+func (e *Error) Error() string {
+	if e.Desc == "" {
+		return e.Status.String() + " : " + e.Op
+	}
+	return e.Status.String() + " : " + e.Op + " : " + e.Desc
+}
+
+//Is reports if target is the sentinel of e.Status, or an *Error with the same Status, Op and Desc.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Status != e.Status {
+		return false
+	}
+	return (t.Op == "" && t.Desc == "") || (t.Op == e.Op && t.Desc == e.Desc)
+}
+
+//String returns the name of the miopenStatus_t value
+func (s Status) String() string {
+	switch s {
+	case statusSuccess:
+		return "miopenStatusSuccess"
+	case statusNotInitialized:
+		return "miopenStatusNotInitialized"
+	case statusInvalidValue:
+		return "miopenStatusInvalidValue"
+	case statusBadParm:
+		return "miopenStatusBadParm"
+	case statusAllocFailed:
+		return "miopenStatusAllocFailed"
+	case statusInternalError:
+		return "miopenStatusInternalError"
+	case statusNotImplemented:
+		return "miopenStatusNotImplemented"
+	case statusUnknownError:
+		return "miopenStatusUnknownError"
+	case statusUnsupportedOp:
+		return "miopenStatusUnsupportedOp"
+	}
+	return fmt.Sprintf("miopenStatus(%d)", int(s))
+}
+
+//error returns nil on success, and an *Error with the comment as its Op otherwise.
+func (s Status) error(comment string) error {
+	if s == statusSuccess {
+		return nil
+	}
+	return &Error{Status: s, Op: comment}
+}
+
+//withdesc adds a summary of the descriptors to err if it is an *Error without one.
+//
+//namesanddescs alternates between the name of a descriptor and the descriptor.
+func withdesc(err error, namesanddescs ...interface{}) error {
+	e, ok := err.(*Error)
+	if !ok || e.Desc != "" {
+		return err
+	}
+	parts := make([]string, 0, len(namesanddescs)/2)
+	for i := 0; i+1 < len(namesanddescs); i += 2 {
+		parts = append(parts, fmt.Sprintf("%v=%s", namesanddescs[i], summary(namesanddescs[i+1])))
+	}
+	return &Error{Status: e.Status, Op: e.Op, Desc: strings.Join(parts, " ")}
+}
+
+//summary returns a short description of a descriptor made from its Get method.
+func summary(d interface{}) string {
+	switch d := d.(type) {
+	case *TensorD:
+		if d == nil {
+			return "nil"
+		}
+		dtype, shape, stride, err := d.Get()
+		if err != nil {
+			return "unset"
+		}
+		return fmt.Sprintf("%s%v/%v", dtype.ToString(), shape, stride)
+	case *ConvolutionD:
+		if d == nil {
+			return "nil"
+		}
+		pad, stride, dilation, mode, err := d.Get()
+		if err != nil {
+			return "unset"
+		}
+		return fmt.Sprintf("{mode:%d pad:%v stride:%v dilation:%v groups:%d}", mode, pad, stride, dilation, d.groupcount())
+	case *PoolingD:
+		if d == nil {
+			return "nil"
+		}
+		mode, window, pad, stride, err := d.Get()
+		if err != nil {
+			return "unset"
+		}
+		return fmt.Sprintf("{mode:%d window:%v pad:%v stride:%v}", mode, window, pad, stride)
+	case *ActivationD:
+		if d == nil {
+			return "nil"
+		}
+		mode, alpha, beta, gamma, err := d.Get()
+		if err != nil {
+			return "unset"
+		}
+		return fmt.Sprintf("{mode:%d alpha:%g beta:%g gamma:%g}", mode, alpha, beta, gamma)
+	case *LRND:
+		if d == nil {
+			return "nil"
+		}
+		mode, n, alpha, beta, k, err := d.Get()
+		if err != nil {
+			return "unset"
+		}
+		return fmt.Sprintf("{mode:%d n:%d alpha:%g beta:%g k:%g}", mode, n, alpha, beta, k)
+	case *BatchNormD:
+		if d == nil {
+			return "nil"
+		}
+		mode, err := d.Get()
+		if err != nil {
+			return "unset"
+		}
+		return fmt.Sprintf("{mode:%d}", mode)
+	case *ReduceTensorD:
+		if d == nil {
+			return "nil"
+		}
+		op, comp, nan, indices, itype, err := d.Get()
+		if err != nil {
+			return "unset"
+		}
+		return fmt.Sprintf("{op:%d comp:%s nan:%d indices:%d itype:%d}", op, comp.ToString(), nan, indices, itype)
+	}
+	return fmt.Sprint(d)
+}
@@ -0,0 +1,32 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"errors"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestErrorIs(t *testing.T) {
+	h := miopen.CreateHandle()
+	a, err := miopen.CreateActivationDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mode miopen.ActivationMode
+	if err = a.Set(mode.Relu(), 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	xD, yD := tensor(t, 1, 4, 1, 1), tensor(t, 1, 3, 1, 1)
+	err = a.Forward(h, 1, xD, make(floats, 4), 0, yD, make(floats, 3))
+	if !errors.Is(err, miopen.ErrBadParm) || errors.Is(err, miopen.ErrNotImplemented) {
+		t.Fatal("expected a BadParm error, got", err)
+	}
+	var merr *miopen.Error
+	if !errors.As(err, &merr) || merr.Desc == "" {
+		t.Fatal("expected the descriptors in the error, got", err)
+	}
+}
@@ -0,0 +1,281 @@
+package miopen
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//FindCache keeps the results of the convolution Find functions so they don't have to search again.  Set it on
+//a handle with SetFindCache, and save it to a file with Save so the next run of a program can load it and
+//skip the search.
+//
+//Results are keyed by the device name, the data type, the shapes and strides of the tensors, the
+//convolution's pad, stride, dilation, group count and mode, and the FindOptions that change the search.
+//The workspace size isn't part of the key, it is kept with the results.  A Find function given less workspace
+//than the cached search drops the results that don't fit, and one given more searches again, as does one left
+//with no results.  The file is JSON.
+type FindCache struct {
+	mux     sync.Mutex
+	entries map[string]findentry
+}
+
+type findentry struct {
+	Key       findkey     `json:"key"`
+	Workspace uint        `json:"workspace"` //Workspace is the wspaceSIB the search was given
+	Perfs     []traceperf `json:"perfs"`
+}
+
+type findkey struct {
+	Device   string          `json:"device"`
+	Op       string          `json:"op"`
+	DataType string          `json:"dtype"`
+	X        tensorkey       `json:"x"`
+	W        tensorkey       `json:"w"`
+	Y        tensorkey       `json:"y"`
+	Pad      []int32         `json:"pad"`
+	Stride   []int32         `json:"stride"`
+	Dilation []int32         `json:"dilation"`
+	Groups   int32           `json:"groups"`
+	Mode     ConvolutionMode `json:"mode"`
+
+	Request            int  `json:"request"`
+	NoExhaustiveSearch bool `json:"noexhaustivesearch,omitempty"`
+	//Deterministic is set for backward searches in deterministic mode, MIOpen only returns the deterministic
+	//algorithms for them if it has Feature.DeterministicConvolution.
+	Deterministic bool `json:"deterministic,omitempty"`
+}
+
+type tensorkey struct {
+	Shape  []int32 `json:"shape"`
+	Stride []int32 `json:"stride"`
+}
+
+type findcachefile struct {
+	Entries []findentry `json:"entries"`
+}
+
+//NewFindCache returns an empty FindCache
+func NewFindCache() *FindCache {
+	return &FindCache{entries: make(map[string]findentry)}
+}
+
+//LoadFindCache reads a cache saved with Save.  If the file doesn't exist an empty cache is returned.
+func LoadFindCache(path string) (*FindCache, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return NewFindCache(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadFindCache(f)
+}
+
+//ReadFindCache reads a cache written with Write
+func ReadFindCache(r io.Reader) (*FindCache, error) {
+	var file findcachefile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+	f := NewFindCache()
+	for _, e := range file.Entries {
+		f.entries[e.Key.String()] = e
+	}
+	return f, nil
+}
+
+//Write writes the cache to w as JSON.  Entries are sorted so the same cache is always written the same way.
+func (f *FindCache) Write(w io.Writer) error {
+	f.mux.Lock()
+	keys := make([]string, 0, len(f.entries))
+	for k := range f.entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	file := findcachefile{Entries: make([]findentry, len(keys))}
+	for i, k := range keys {
+		file.Entries[i] = f.entries[k]
+	}
+	f.mux.Unlock()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(file)
+}
+
+//Save writes the cache to path.  It is written to a temporary file first, so a program that is killed while
+//saving doesn't leave a broken cache behind.
+func (f *FindCache) Save(path string) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	err = f.Write(tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+//Len returns the number of searches that are cached
+func (f *FindCache) Len() int {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return len(f.entries)
+}
+
+func (k findkey) String() string {
+	b, _ := json.Marshal(k)
+	return string(b)
+}
+
+//key returns the key of a search.  ok is false if there is no cache or a descriptor can't be read.
+func (f *FindCache) key(h *Handle, op string, c *ConvolutionD, xD, wD, yD *TensorD, opts FindOptions) (key string, ok bool) {
+	if f == nil {
+		return "", false
+	}
+	return searchkey(h, op, c, xD, wD, yD, opts)
+}
+
+//searchkey returns a key that is the same for searches that return the same results.  ok is false if a
+//descriptor can't be read.
+func searchkey(h *Handle, op string, c *ConvolutionD, xD, wD, yD *TensorD, opts FindOptions) (key string, ok bool) {
+	k := findkey{Device: h.devicename(), Op: op, Groups: c.groupcount(), Request: opts.Request, NoExhaustiveSearch: opts.NoExhaustiveSearch}
+	k.Deterministic = h.deterministic && op != "forward"
+	var err error
+	k.Pad, k.Stride, k.Dilation, k.Mode, err = c.Get()
+	if err != nil {
+		return "", false
+	}
+	for _, t := range []struct {
+		d *TensorD
+		k *tensorkey
+	}{{xD, &k.X}, {wD, &k.W}, {yD, &k.Y}} {
+		var dtype DataType
+		dtype, t.k.Shape, t.k.Stride, err = t.d.Get()
+		if err != nil {
+			return "", false
+		}
+		if t.d == xD {
+			k.DataType = dtype.ToString()
+		}
+	}
+	return k.String(), true
+}
+
+func (f *FindCache) get(key string) (findentry, bool) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	e, ok := f.entries[key]
+	return e, ok
+}
+
+func (f *FindCache) put(key string, wspaceSIB uint, perfs []traceperf) {
+	var e findentry
+	if json.Unmarshal([]byte(key), &e.Key) != nil {
+		return
+	}
+	e.Workspace, e.Perfs = wspaceSIB, perfs
+	f.mux.Lock()
+	f.entries[key] = e
+	f.mux.Unlock()
+}
+
+//SetFindCache sets the cache used by the convolution Find functions run on h.  Passing nil stops caching.
+func (h *Handle) SetFindCache(f *FindCache) {
+	h.fc = f
+}
+
+//FindCache returns the cache set with SetFindCache
+func (h *Handle) FindCache() *FindCache {
+	return h.fc
+}
+
+//find returns the results of search, or of an earlier search from the cache, with the results that need
+//too much workspace, or aren't deterministic in deterministic mode, dropped.
+//
+//The cache is only used if its search was given at least wspaceSIB and some of its results are left, otherwise
+//search runs again.  Its results are cached unless a search with a bigger workspace already is.
+func (h *Handle) find(op string, c *ConvolutionD, xD, wD, yD *TensorD, wspaceSIB uint, opts FindOptions, search func() ([]traceperf, error)) ([]traceperf, error) {
+	key, ok := h.fc.key(h, op, c, xD, wD, yD, opts)
+	var e findentry
+	var hit bool
+	if ok {
+		if e, hit = h.fc.get(key); hit && e.Workspace >= wspaceSIB {
+			if perfs := h.deterministicperfs(op, opts.filter(fitperfs(e.Perfs, wspaceSIB))); len(perfs) > 0 {
+				return perfs, nil
+			}
+		}
+	}
+	perfs, err := search()
+	if err != nil {
+		return nil, err
+	}
+	if ok && (!hit || wspaceSIB >= e.Workspace) {
+		h.fc.put(key, wspaceSIB, perfs)
+	}
+	return h.deterministicperfs(op, opts.filter(perfs)), nil
+}
+
+//fitperfs returns the perfs that need at most wspaceSIB bytes of workspace
+func fitperfs(perfs []traceperf, wspaceSIB uint) []traceperf {
+	kept := make([]traceperf, 0, len(perfs))
+	for _, p := range perfs {
+		if p.Memory <= wspaceSIB {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func (h *Handle) findforward(c *ConvolutionD,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvFwdAlgoPerf, error) {
+	perfs, err := h.find("forward", c, xD, wD, yD, wspaceSIB, opts, func() ([]traceperf, error) {
+		results, err := h.b.FindConvolutionForwardAlgorithm(c, xD, x, wD, w, yD, y, wspace, wspaceSIB, opts)
+		return fwdtraceperfs(results), err
+	})
+	return fwdperfs(perfs), err
+}
+
+func (h *Handle) findbackwarddata(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvBwdDataAlgoPerf, error) {
+	perfs, err := h.find("backwarddata", c, dxD, wD, dyD, wspaceSIB, opts, func() ([]traceperf, error) {
+		results, err := h.b.FindConvolutionBackwardDataAlgorithm(c, dyD, dy, wD, w, dxD, dx, wspace, wspaceSIB, opts)
+		return bwddatatraceperfs(results), err
+	})
+	return bwddataperfs(perfs), err
+}
+
+func (h *Handle) findbackwardweights(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvBwdWeightAlgoPerf, error) {
+	perfs, err := h.find("backwardweights", c, xD, dwD, dyD, wspaceSIB, opts, func() ([]traceperf, error) {
+		results, err := h.b.FindConvolutionBackwardWeightsAlgorithm(c, dyD, dy, xD, x, dwD, dw, wspace, wspaceSIB, opts)
+		return bwdweighttraceperfs(results), err
+	})
+	return bwdweightperfs(perfs), err
+}
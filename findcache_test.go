@@ -0,0 +1,103 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dereklstinson/cutil"
+	miopen "github.com/dereklstinson/migo"
+)
+
+//findcounter counts the forward convolution searches that reach the backend
+type findcounter struct {
+	miopen.Backend
+	n    int
+	opts miopen.FindOptions
+}
+
+func (f *findcounter) FindConvolutionForwardAlgorithm(c *miopen.ConvolutionD,
+	xD *miopen.TensorD, x cutil.Mem,
+	wD *miopen.TensorD, w cutil.Mem,
+	yD *miopen.TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts miopen.FindOptions) ([]miopen.ConvFwdAlgoPerf, error) {
+	f.n++
+	f.opts = opts
+	return f.Backend.FindConvolutionForwardAlgorithm(c, xD, x, wD, w, yD, y, wspace, wspaceSIB, opts)
+}
+
+func TestFindCache(t *testing.T) {
+	counter := &findcounter{Backend: miopen.NewCPUBackend()}
+	h, err := miopen.NewHandle(miopen.WithBackend(counter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.SetFindCache(miopen.NewFindCache())
+	c, xD, wD, yD := convfixture(t)
+	x, w, y := make(floats, 9), make(floats, 4), make(floats, 4)
+	want, err := c.FindForwardAlgorithm(h, xD, x, wD, w, yD, y, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = h.FindCache().Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := miopen.ReadFindCache(&buf)
+	if err != nil || loaded.Len() != 1 {
+		t.Fatal("expected one cached search, got", loaded, err)
+	}
+	h.SetFindCache(loaded)
+	got, err := c.FindForwardAlgorithm(h, xD, x, wD, w, yD, y, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counter.n != 1 || len(got) != len(want) {
+		t.Fatal("expected the second search to come from the cache", counter.n, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Error("cached perf", got[i], "!=", want[i])
+		}
+	}
+	opts := miopen.FindOptions{Request: 2, NoExhaustiveSearch: true}
+	if _, err = c.FindForwardAlgorithm(h, xD, x, wD, w, yD, y, nil, 0, opts); err != nil {
+		t.Fatal(err)
+	}
+	if counter.n != 2 || counter.opts != opts {
+		t.Fatal("expected a search with different options to reach the backend with them", counter.n, counter.opts)
+	}
+	buf.Reset()
+	if err = h.FindCache().Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	//a cached search that was given 64 bytes of workspace, and picked an algorithm that needs all of it
+	edited := strings.NewReplacer(`"memory": 0`, `"memory": 64`, `"workspace": 0`, `"workspace": 64`).Replace(buf.String())
+	if loaded, err = miopen.ReadFindCache(strings.NewReader(edited)); err != nil {
+		t.Fatal(err)
+	}
+	h.SetFindCache(loaded)
+	for _, tc := range []struct {
+		wspaceSIB uint
+		searches  int
+	}{
+		{0, 3},   //the cached perf doesn't fit, so Find searches again
+		{64, 3},  //the cached perf fits
+		{128, 4}, //the cached search was given less workspace
+	} {
+		var wspace cutil.Mem
+		if tc.wspaceSIB > 0 {
+			wspace = make(floats, tc.wspaceSIB/4)
+		}
+		if got, err = c.FindForwardAlgorithm(h, xD, x, wD, w, yD, y, wspace, tc.wspaceSIB); err != nil || len(got) != 1 {
+			t.Fatal("expected one perf with a workspace of", tc.wspaceSIB, "got", got, err)
+		}
+		if counter.n != tc.searches {
+			t.Fatal("expected", tc.searches, "searches with a workspace of", tc.wspaceSIB, "got", counter.n)
+		}
+	}
+}
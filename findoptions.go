@@ -0,0 +1,39 @@
+package miopen
+
+//FindOptions changes how the convolution Find functions search.  The zero value is the default search.
+type FindOptions struct {
+	//Request is the most results that are returned.  If it is less than 1 it is 4.
+	Request int `json:"request"`
+	//NoExhaustiveSearch returns the kernels MIOpen already knows about for the configuration, if it has any,
+	//instead of timing every algorithm.  It is much faster but the kernels might not be the fastest.
+	NoExhaustiveSearch bool `json:"noexhaustivesearch"`
+	//MaxWorkspace drops results that need a workspace larger than MaxWorkspace bytes. 0 is no limit.
+	//
+	//MIOpen already skips algorithms that need more than the wspaceSIB passed to Find.
+	MaxWorkspace uint `json:"maxworkspace"`
+}
+
+func findoptions(opts []FindOptions) FindOptions {
+	var o FindOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Request < 1 {
+		o.Request = 4
+	}
+	return o
+}
+
+//filter drops the results that need more than MaxWorkspace bytes
+func (o FindOptions) filter(perfs []traceperf) []traceperf {
+	if o.MaxWorkspace == 0 {
+		return perfs
+	}
+	kept := make([]traceperf, 0, len(perfs))
+	for _, p := range perfs {
+		if p.Memory <= o.MaxWorkspace {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
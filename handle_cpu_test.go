@@ -0,0 +1,31 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"testing"
+	"unsafe"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+//stream is a Streamer for the cpu build
+type stream struct{}
+
+func (s *stream) Ptr() unsafe.Pointer { return unsafe.Pointer(s) }
+func (s *stream) Sync() error         { return nil }
+
+func TestNewHandle(t *testing.T) {
+	s := new(stream)
+	h, err := miopen.NewHandle(miopen.WithStream(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := h.GetStream(); err != nil || got != s {
+		t.Fatal("expected the stream passed to NewHandle, got", got, err)
+	}
+	if err = h.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+}
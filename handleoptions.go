@@ -0,0 +1,32 @@
+package miopen
+
+//HandleOption configures a Handle made by NewHandle
+type HandleOption func(*handleconfig)
+
+type handleconfig struct {
+	stream  Streamer
+	backend Backend
+}
+
+func handleoptions(opts []HandleOption) (c handleconfig) {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return c
+}
+
+//WithStream creates the handle bound to s (miopenCreateWithStream) instead of the default stream.
+func WithStream(s Streamer) HandleOption {
+	return func(c *handleconfig) {
+		c.stream = s
+	}
+}
+
+//WithBackend creates the handle with b as its Backend.  It is the same as calling SetBackend(b) after.
+func WithBackend(b Backend) HandleOption {
+	return func(c *handleconfig) {
+		c.backend = b
+	}
+}
@@ -0,0 +1,152 @@
+package miopen
+
+import (
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+	"github.com/dereklstinson/half"
+)
+
+//hostview reads and writes the host memory described by a TensorD. Kernels in the cpu backend
+//work on dense float64 slices in packed (row-major) order.  load and store take care of the
+//descriptor's strides and data type.
+type hostview struct {
+	p      unsafe.Pointer
+	dtype  DataType
+	shape  []int32
+	stride []int32
+}
+
+func viewof(tD *TensorD, m cutil.Mem, comment string) (*hostview, error) {
+	if tD == nil {
+		return nil, statusBadParm.error(comment + ": nil tensor descriptor")
+	}
+	dtype, shape, stride, err := tD.Get()
+	if err != nil {
+		return nil, statusBadParm.error(comment + ": tensor descriptor not set")
+	}
+	if m == nil || m.Ptr() == nil {
+		return nil, statusBadParm.error(comment + ": nil memory")
+	}
+	var flg DataType
+	switch dtype {
+	case flg.Float(), flg.Half(), flg.Int32(), flg.Int8():
+	default:
+		return nil, statusNotImplemented.error(comment + ": DataType " + dtype.ToString() + " not supported by cpu backend")
+	}
+	return &hostview{p: m.Ptr(), dtype: dtype, shape: shape, stride: stride}, nil
+}
+
+func (v *hostview) volume() int {
+	return int(findvolume(v.shape))
+}
+
+func (v *hostview) get(off int) float64 {
+	var flg DataType
+	switch v.dtype {
+	case flg.Float():
+		return float64(*(*float32)(unsafe.Pointer(uintptr(v.p) + uintptr(off)*4)))
+	case flg.Half():
+		return float64((*(*half.Float16)(unsafe.Pointer(uintptr(v.p) + uintptr(off)*2))).Float32())
+	case flg.Int32():
+		return float64(*(*int32)(unsafe.Pointer(uintptr(v.p) + uintptr(off)*4)))
+	case flg.Int8():
+		return float64(*(*int8)(unsafe.Pointer(uintptr(v.p) + uintptr(off))))
+	}
+	return 0
+}
+
+func (v *hostview) put(off int, x float64) {
+	var flg DataType
+	switch v.dtype {
+	case flg.Float():
+		*(*float32)(unsafe.Pointer(uintptr(v.p) + uintptr(off)*4)) = float32(x)
+	case flg.Half():
+		*(*half.Float16)(unsafe.Pointer(uintptr(v.p) + uintptr(off)*2)) = half.NewFloat16(float32(x))
+	case flg.Int32():
+		*(*int32)(unsafe.Pointer(uintptr(v.p) + uintptr(off)*4)) = int32(x)
+	case flg.Int8():
+		*(*int8)(unsafe.Pointer(uintptr(v.p) + uintptr(off))) = int8(x)
+	}
+}
+
+//offsets returns the memory offset of every element in packed order.
+func (v *hostview) offsets() []int {
+	offs := make([]int, 0, v.volume())
+	forEachIndex(v.shape, func(idx []int32) {
+		off := 0
+		for i := range idx {
+			off += int(idx[i]) * int(v.stride[i])
+		}
+		offs = append(offs, off)
+	})
+	return offs
+}
+
+//load copies the tensor into a dense slice
+func (v *hostview) load() []float64 {
+	offs := v.offsets()
+	vals := make([]float64, len(offs))
+	for i, off := range offs {
+		vals[i] = v.get(off)
+	}
+	return vals
+}
+
+//store writes y = alpha*vals + beta*y.  y is not read when beta is zero.
+func (v *hostview) store(vals []float64, alpha, beta float64) {
+	for i, off := range v.offsets() {
+		if beta == 0 {
+			v.put(off, alpha*vals[i])
+		} else {
+			v.put(off, alpha*vals[i]+beta*v.get(off))
+		}
+	}
+}
+
+//forEachIndex calls f with every index of shape in row-major order.  idx is reused between calls.
+func forEachIndex(shape []int32, f func(idx []int32)) {
+	if findvolume(shape) == 0 {
+		return
+	}
+	idx := make([]int32, len(shape))
+	for {
+		f(idx)
+		i := len(shape) - 1
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < shape[i] {
+				break
+			}
+			idx[i] = 0
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+//broadcastmap returns for every packed index of dst the packed index of src, where every
+//dimension of src is either equal to dst or 1.
+func broadcastmap(dst, src []int32) ([]int, bool) {
+	if len(dst) != len(src) {
+		return nil, false
+	}
+	for i := range dst {
+		if src[i] != dst[i] && src[i] != 1 {
+			return nil, false
+		}
+	}
+	sstride := stridecalc(src)
+	m := make([]int, 0, findvolume(dst))
+	forEachIndex(dst, func(idx []int32) {
+		off := 0
+		for i := range idx {
+			if src[i] != 1 {
+				off += int(idx[i]) * int(sstride[i])
+			}
+		}
+		m = append(m, off)
+	})
+	return m, true
+}
@@ -0,0 +1,311 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//ConvSolution is a solution of a convolution in immediate mode.  Immediate mode runs a convolution without
+//Find, which needs real buffers and a long search before the first pass.  MIOpen picks the solutions from
+//its database, or from heuristics if the configuration isn't in it.
+//
+//	solutions, err := c.GetFwdSolutions(h, wD, xD, yD, 1)
+//	//allocate a workspace of solutions[0].WorkspaceSize bytes
+//	err = c.ForwardImmediate(h, wD, w, xD, x, yD, y, wspace, solutions[0].WorkspaceSize, solutions[0].ID)
+type ConvSolution struct {
+	ID            uint64        `json:"id"`            //ID identifies the solution in the Compile and Immediate functions
+	Time          float32       `json:"time"`          //Time is the estimated time in ms. It is negative if MIOpen has no estimate.
+	WorkspaceSize uint          `json:"workspacesize"` //WorkspaceSize is the workspace needed in bytes
+	Algorithm     ConvAlgorithm `json:"algorithm"`     //Algorithm is the algorithm the solution uses
+}
+
+//immediatemode is the Feature every immediate mode function needs
+var immediatemode = new(Feature).ImmediateMode()
+
+//GetFwdSolutionCount - Query the maximum number of solutions applicable for the forward convolution
+//in immediate mode.
+//
+//	wD		Tensor descriptor for weight tensor w (input)
+//	xD		Tensor descriptor for input data tensor x (input)
+//	yD		Tensor descriptor for output data tensor y (input)
+func (c *ConvolutionD) GetFwdSolutionCount(h *Handle, wD, xD, yD *TensorD) (count uint, err error) {
+	k := check{op: "(*ConvolutionD)GetFwdSolutionCount()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("xD", xD), k.desc("wD", wD), k.desc("yD", yD))
+	if k.err == nil {
+		count, err = h.b.ConvolutionForwardGetSolutionCount(c, wD, xD, yD)
+	} else {
+		err = k.err
+	}
+	return count, withdesc(err, "c", c, "wD", wD, "xD", xD, "yD", yD)
+}
+
+//GetFwdSolutions - Query the applicable solutions for the forward convolution in immediate mode.
+//
+//The solutions are sorted by their estimated time, fastest first.  If max is 0 every applicable solution
+//is returned.
+//
+//	wD		Tensor descriptor for weight tensor w (input)
+//	xD		Tensor descriptor for input data tensor x (input)
+//	yD		Tensor descriptor for output data tensor y (input)
+//	max		The most solutions returned (input)
+func (c *ConvolutionD) GetFwdSolutions(h *Handle, wD, xD, yD *TensorD, max uint) (solutions []ConvSolution, err error) {
+	k := check{op: "(*ConvolutionD)GetFwdSolutions()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("xD", xD), k.desc("wD", wD), k.desc("yD", yD))
+	err = k.err
+	if err == nil && max == 0 {
+		max, err = h.b.ConvolutionForwardGetSolutionCount(c, wD, xD, yD)
+	}
+	if err == nil {
+		solutions, err = h.b.ConvolutionForwardGetSolution(c, wD, xD, yD, max)
+	}
+	return solutions, withdesc(err, "c", c, "wD", wD, "xD", xD, "yD", yD)
+}
+
+//GetFwdSolutionWorkspaceSize - Returns the workspace size in bytes needed by a solution of the forward
+//convolution.
+func (c *ConvolutionD) GetFwdSolutionWorkspaceSize(h *Handle, wD, xD, yD *TensorD, solutionID uint64) (wspaceSIB uint, err error) {
+	k := check{op: "(*ConvolutionD)GetFwdSolutionWorkspaceSize()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("xD", xD), k.desc("wD", wD), k.desc("yD", yD))
+	if k.err == nil {
+		wspaceSIB, err = h.b.ConvolutionForwardGetSolutionWorkspaceSize(c, wD, xD, yD, solutionID)
+	} else {
+		err = k.err
+	}
+	return wspaceSIB, withdesc(err, "c", c, "wD", wD, "xD", xD, "yD", yD)
+}
+
+//CompileFwdSolution - Compiles the kernels of a solution of the forward convolution.  It is optional,
+//ForwardImmediate compiles them the first time it is run, but it can be used to move compilation out of the
+//first pass.
+func (c *ConvolutionD) CompileFwdSolution(h *Handle, wD, xD, yD *TensorD, solutionID uint64) error {
+	k := check{op: "(*ConvolutionD)CompileFwdSolution()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("xD", xD), k.desc("wD", wD), k.desc("yD", yD))
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionForwardCompileSolution(c, wD, xD, yD, solutionID)
+	}
+	return withdesc(err, "c", c, "wD", wD, "xD", xD, "yD", yD)
+}
+
+//ForwardImmediate - Runs the forward convolution with a solution returned by GetFwdSolutions.
+//
+//Unlike Forward() no Find is needed first.  There are no alpha and beta, y is overwritten.
+//
+//	wspace		Workspace of at least GetFwdSolutionWorkspaceSize() bytes (input)
+//	wspaceSIB	Size in bytes of wspace (input)
+//	solutionID	ID of the solution to run (input)
+func (c *ConvolutionD) ForwardImmediate(h *Handle,
+	wD *TensorD, w cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	k := check{op: "(*ConvolutionD)ForwardImmediate()"}
+	k.supports(immediatemode)
+	k.conv(c, k.tensor("xD", xD, x), k.tensor("wD", wD, w), k.tensor("yD", yD, y))
+	k.wspace(wspace, wspaceSIB)
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionForwardImmediate(c, wD, w, xD, x, yD, y, wspace, wspaceSIB, solutionID)
+	}
+	return withdesc(err, "c", c, "wD", wD, "xD", xD, "yD", yD)
+}
+
+//GetBwdDataSolutionCount - Query the maximum number of solutions applicable for the backward data convolution
+//in immediate mode.
+//
+//	dyD		Tensor descriptor for data input tensor dy (input)
+//	wD		Tensor descriptor for weight tensor w (input)
+//	dxD		Tensor descriptor for output data tensor dx (input)
+func (c *ConvolutionD) GetBwdDataSolutionCount(h *Handle, dyD, wD, dxD *TensorD) (count uint, err error) {
+	k := check{op: "(*ConvolutionD)GetBwdDataSolutionCount()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("dxD", dxD), k.desc("wD", wD), k.desc("dyD", dyD))
+	k.setdeterministic(h, c)
+	if k.err == nil {
+		count, err = h.b.ConvolutionBackwardDataGetSolutionCount(c, dyD, wD, dxD)
+	} else {
+		err = k.err
+	}
+	return count, withdesc(err, "c", c, "dyD", dyD, "wD", wD, "dxD", dxD)
+}
+
+//GetBwdDataSolutions - Query the applicable solutions for the backward data convolution in immediate mode.
+//
+//The solutions are sorted by their estimated time, fastest first.  If max is 0 every applicable solution
+//is returned.
+//
+//	dyD		Tensor descriptor for data input tensor dy (input)
+//	wD		Tensor descriptor for weight tensor w (input)
+//	dxD		Tensor descriptor for output data tensor dx (input)
+//	max		The most solutions returned (input)
+func (c *ConvolutionD) GetBwdDataSolutions(h *Handle, dyD, wD, dxD *TensorD, max uint) (solutions []ConvSolution, err error) {
+	k := check{op: "(*ConvolutionD)GetBwdDataSolutions()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("dxD", dxD), k.desc("wD", wD), k.desc("dyD", dyD))
+	k.setdeterministic(h, c)
+	err = k.err
+	if err == nil && max == 0 {
+		max, err = h.b.ConvolutionBackwardDataGetSolutionCount(c, dyD, wD, dxD)
+	}
+	if err == nil {
+		solutions, err = h.b.ConvolutionBackwardDataGetSolution(c, dyD, wD, dxD, max)
+		solutions = h.deterministicsolutions(solutions)
+	}
+	return solutions, withdesc(err, "c", c, "dyD", dyD, "wD", wD, "dxD", dxD)
+}
+
+//GetBwdDataSolutionWorkspaceSize - Returns the workspace size in bytes needed by a solution of the backward data
+//convolution.
+func (c *ConvolutionD) GetBwdDataSolutionWorkspaceSize(h *Handle, dyD, wD, dxD *TensorD, solutionID uint64) (wspaceSIB uint, err error) {
+	k := check{op: "(*ConvolutionD)GetBwdDataSolutionWorkspaceSize()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("dxD", dxD), k.desc("wD", wD), k.desc("dyD", dyD))
+	k.setdeterministic(h, c)
+	if k.err == nil {
+		wspaceSIB, err = h.b.ConvolutionBackwardDataGetSolutionWorkspaceSize(c, dyD, wD, dxD, solutionID)
+	} else {
+		err = k.err
+	}
+	return wspaceSIB, withdesc(err, "c", c, "dyD", dyD, "wD", wD, "dxD", dxD)
+}
+
+//CompileBwdDataSolution - Compiles the kernels of a solution of the backward data convolution.  It is optional,
+//BackwardDataImmediate compiles them the first time it is run, but it can be used to move compilation out of the
+//first pass.
+func (c *ConvolutionD) CompileBwdDataSolution(h *Handle, dyD, wD, dxD *TensorD, solutionID uint64) error {
+	k := check{op: "(*ConvolutionD)CompileBwdDataSolution()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("dxD", dxD), k.desc("wD", wD), k.desc("dyD", dyD))
+	k.setdeterministic(h, c)
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionBackwardDataCompileSolution(c, dyD, wD, dxD, solutionID)
+	}
+	return withdesc(err, "c", c, "dyD", dyD, "wD", wD, "dxD", dxD)
+}
+
+//BackwardDataImmediate - Runs the backward data convolution with a solution returned by GetBwdDataSolutions.
+//
+//Unlike BackwardData() no Find is needed first.  There are no alpha and beta, dx is overwritten.
+//
+//	wspace		Workspace of at least GetBwdDataSolutionWorkspaceSize() bytes (input)
+//	wspaceSIB	Size in bytes of wspace (input)
+//	solutionID	ID of the solution to run (input)
+func (c *ConvolutionD) BackwardDataImmediate(h *Handle,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	k := check{op: "(*ConvolutionD)BackwardDataImmediate()"}
+	k.supports(immediatemode)
+	k.conv(c, k.tensor("dxD", dxD, dx), k.tensor("wD", wD, w), k.tensor("dyD", dyD, dy))
+	k.wspace(wspace, wspaceSIB)
+	k.setdeterministic(h, c)
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionBackwardDataImmediate(c, dyD, dy, wD, w, dxD, dx, wspace, wspaceSIB, solutionID)
+	}
+	return withdesc(err, "c", c, "dyD", dyD, "wD", wD, "dxD", dxD)
+}
+
+//GetBwdWeightsSolutionCount - Query the maximum number of solutions applicable for the backward weights convolution
+//in immediate mode.
+//
+//	dyD		Tensor descriptor for data input tensor dy (input)
+//	xD		Tensor descriptor for input data tensor x (input)
+//	dwD		Tensor descriptor for weight delta tensor dw (input)
+func (c *ConvolutionD) GetBwdWeightsSolutionCount(h *Handle, dyD, xD, dwD *TensorD) (count uint, err error) {
+	k := check{op: "(*ConvolutionD)GetBwdWeightsSolutionCount()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("xD", xD), k.desc("dwD", dwD), k.desc("dyD", dyD))
+	k.setdeterministic(h, c)
+	if k.err == nil {
+		count, err = h.b.ConvolutionBackwardWeightsGetSolutionCount(c, dyD, xD, dwD)
+	} else {
+		err = k.err
+	}
+	return count, withdesc(err, "c", c, "dyD", dyD, "xD", xD, "dwD", dwD)
+}
+
+//GetBwdWeightsSolutions - Query the applicable solutions for the backward weights convolution in immediate mode.
+//
+//The solutions are sorted by their estimated time, fastest first.  If max is 0 every applicable solution
+//is returned.
+//
+//	dyD		Tensor descriptor for data input tensor dy (input)
+//	xD		Tensor descriptor for input data tensor x (input)
+//	dwD		Tensor descriptor for weight delta tensor dw (input)
+//	max		The most solutions returned (input)
+func (c *ConvolutionD) GetBwdWeightsSolutions(h *Handle, dyD, xD, dwD *TensorD, max uint) (solutions []ConvSolution, err error) {
+	k := check{op: "(*ConvolutionD)GetBwdWeightsSolutions()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("xD", xD), k.desc("dwD", dwD), k.desc("dyD", dyD))
+	k.setdeterministic(h, c)
+	err = k.err
+	if err == nil && max == 0 {
+		max, err = h.b.ConvolutionBackwardWeightsGetSolutionCount(c, dyD, xD, dwD)
+	}
+	if err == nil {
+		solutions, err = h.b.ConvolutionBackwardWeightsGetSolution(c, dyD, xD, dwD, max)
+		solutions = h.deterministicsolutions(solutions)
+	}
+	return solutions, withdesc(err, "c", c, "dyD", dyD, "xD", xD, "dwD", dwD)
+}
+
+//GetBwdWeightsSolutionWorkspaceSize - Returns the workspace size in bytes needed by a solution of the backward weights
+//convolution.
+func (c *ConvolutionD) GetBwdWeightsSolutionWorkspaceSize(h *Handle, dyD, xD, dwD *TensorD, solutionID uint64) (wspaceSIB uint, err error) {
+	k := check{op: "(*ConvolutionD)GetBwdWeightsSolutionWorkspaceSize()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("xD", xD), k.desc("dwD", dwD), k.desc("dyD", dyD))
+	k.setdeterministic(h, c)
+	if k.err == nil {
+		wspaceSIB, err = h.b.ConvolutionBackwardWeightsGetSolutionWorkspaceSize(c, dyD, xD, dwD, solutionID)
+	} else {
+		err = k.err
+	}
+	return wspaceSIB, withdesc(err, "c", c, "dyD", dyD, "xD", xD, "dwD", dwD)
+}
+
+//CompileBwdWeightsSolution - Compiles the kernels of a solution of the backward weights convolution.  It is optional,
+//BackwardWeightsImmediate compiles them the first time it is run, but it can be used to move compilation out of the
+//first pass.
+func (c *ConvolutionD) CompileBwdWeightsSolution(h *Handle, dyD, xD, dwD *TensorD, solutionID uint64) error {
+	k := check{op: "(*ConvolutionD)CompileBwdWeightsSolution()"}
+	k.supports(immediatemode)
+	k.conv(c, k.desc("xD", xD), k.desc("dwD", dwD), k.desc("dyD", dyD))
+	k.setdeterministic(h, c)
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionBackwardWeightsCompileSolution(c, dyD, xD, dwD, solutionID)
+	}
+	return withdesc(err, "c", c, "dyD", dyD, "xD", xD, "dwD", dwD)
+}
+
+//BackwardWeightsImmediate - Runs the backward weights convolution with a solution returned by GetBwdWeightsSolutions.
+//
+//Unlike BackwardWeights() no Find is needed first.  There are no alpha and beta, dw is overwritten.
+//
+//	wspace		Workspace of at least GetBwdWeightsSolutionWorkspaceSize() bytes (input)
+//	wspaceSIB	Size in bytes of wspace (input)
+//	solutionID	ID of the solution to run (input)
+func (c *ConvolutionD) BackwardWeightsImmediate(h *Handle,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	k := check{op: "(*ConvolutionD)BackwardWeightsImmediate()"}
+	k.supports(immediatemode)
+	k.conv(c, k.tensor("xD", xD, x), k.tensor("dwD", dwD, dw), k.tensor("dyD", dyD, dy))
+	k.wspace(wspace, wspaceSIB)
+	k.setdeterministic(h, c)
+	err := k.err
+	if err == nil {
+		err = h.b.ConvolutionBackwardWeightsImmediate(c, dyD, dy, xD, x, dwD, dw, wspace, wspaceSIB, solutionID)
+	}
+	return withdesc(err, "c", c, "dyD", dyD, "xD", xD, "dwD", dwD)
+}
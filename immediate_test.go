@@ -0,0 +1,37 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"errors"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestImmediate(t *testing.T) {
+	h := miopen.CreateHandle()
+	c, xD, wD, yD := convfixture(t)
+	solutions, err := c.GetFwdSolutions(h, wD, xD, yD, 0)
+	if err != nil || len(solutions) == 0 {
+		t.Fatal("expected a solution, got", solutions, err)
+	}
+	s := solutions[0]
+	if err = c.CompileFwdSolution(h, wD, xD, yD, s.ID); err != nil {
+		t.Fatal(err)
+	}
+	y := floats{-1, -1, -1, -1}
+	x, w := convdata()
+	if err = c.ForwardImmediate(h, wD, w, xD, x, yD, y, nil, s.WorkspaceSize, s.ID); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range []float32{6, 8, 12, 14} {
+		if y[i] != v {
+			t.Fatal("unexpected output", y)
+		}
+	}
+	if err = c.CompileFwdSolution(h, wD, xD, yD, s.ID+100); !errors.Is(err, miopen.ErrBadParm) {
+		t.Fatal("expected an unknown solution to fail, got", err)
+	}
+}
@@ -0,0 +1,118 @@
+package miopen
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+//lifetime is embedded in everything that holds MIOpen resources.  It makes Destroy idempotent, so the
+//explicit Destroy and the finalizer can't both free the resource, and records the object while leak
+//tracking is on.
+type lifetime struct {
+	mux       sync.Mutex
+	destroyed bool
+	id        uint64
+}
+
+//start records the object as live if leak tracking is on.  It is called once the resource was created.
+func (l *lifetime) start(kind string) {
+	l.id = leaks.add(kind)
+}
+
+//end runs free the first time it is called and does nothing after.
+func (l *lifetime) end(free func() error) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.destroyed {
+		return nil
+	}
+	l.destroyed = true
+	leaks.remove(l.id)
+	if free == nil {
+		return nil
+	}
+	return free()
+}
+
+//Leak is a handle or descriptor that was created while leak tracking was on and has not been destroyed,
+//either with Destroy or by the garbage collector.
+type Leak struct {
+	Kind  string //Kind is the type, e.g. "TensorD"
+	Stack string //Stack is where it was created
+}
+
+type leaktracker struct {
+	mux  sync.Mutex
+	on   bool
+	next uint64
+	live map[uint64]Leak
+}
+
+var leaks = leaktracker{on: os.Getenv("MIGO_TRACKLEAKS") != "", live: make(map[uint64]Leak)}
+
+func (t *leaktracker) add(kind string) uint64 {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if !t.on {
+		return 0
+	}
+	buf := make([]byte, 4096)
+	buf = buf[:runtime.Stack(buf, false)]
+	t.next++
+	t.live[t.next] = Leak{Kind: kind, Stack: string(buf)}
+	return t.next
+}
+
+func (t *leaktracker) remove(id uint64) {
+	if id == 0 {
+		return
+	}
+	t.mux.Lock()
+	delete(t.live, id)
+	t.mux.Unlock()
+}
+
+//TrackLeaks turns leak tracking on or off.  It can also be turned on by setting the MIGO_TRACKLEAKS
+//environment variable.
+//
+//While it is on, every handle and descriptor that is created is recorded with the stack that created it
+//until it is destroyed.  It is meant for debugging since it slows down descriptor creation.
+func TrackLeaks(on bool) {
+	leaks.mux.Lock()
+	leaks.on = on
+	leaks.mux.Unlock()
+}
+
+//Leaks returns the handles and descriptors created while leak tracking was on that haven't been destroyed,
+//in the order they were created.
+//
+//Objects that are unreachable but haven't been finalized yet are included.  Call runtime.GC() first to
+//only see the ones that are still referenced.
+func Leaks() []Leak {
+	leaks.mux.Lock()
+	defer leaks.mux.Unlock()
+	ids := make([]uint64, 0, len(leaks.live))
+	for id := range leaks.live {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	l := make([]Leak, len(ids))
+	for i, id := range ids {
+		l[i] = leaks.live[id]
+	}
+	return l
+}
+
+//ReportLeaks writes Leaks() to w and returns how many there were.  A long running service can defer it in
+//main to find descriptors that were never destroyed before the process exits.
+func ReportLeaks(w io.Writer) int {
+	l := Leaks()
+	for _, leak := range l {
+		fmt.Fprintf(w, "miopen: %s was never destroyed. Created at:\n%s\n", leak.Kind, leak.Stack)
+	}
+	return len(l)
+}
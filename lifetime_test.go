@@ -0,0 +1,38 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"bytes"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestDestroy(t *testing.T) {
+	miopen.TrackLeaks(true)
+	defer miopen.TrackLeaks(false)
+	before := len(miopen.Leaks())
+	xD := tensor(t, 1, 2, 3, 4)
+	c, err := miopen.CreateConvolutionDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaks := miopen.Leaks()
+	if len(leaks)-before != 2 || leaks[len(leaks)-1].Kind != "ConvolutionD" {
+		t.Fatal("expected the tensor and convolution descriptors to be tracked, got", leaks)
+	}
+	for i := 0; i < 2; i++ {
+		if err = xD.Destroy(); err != nil {
+			t.Fatal(err)
+		}
+		if err = c.Destroy(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if n := miopen.ReportLeaks(&buf); n != before {
+		t.Fatal("destroyed descriptors are still reported\n", buf.String())
+	}
+}
@@ -0,0 +1,67 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//Forward - Execute a LRN forward layer
+//
+//Runs the forward layer normalization in the forward direction. If doBackwards == 0, then
+//set workSpace = nullptr. However, if the user wishes to execute backwards,
+//then they must set doBackwards = 1 in miopenLRNForward().
+//
+//	h         MIOpen handle (input)
+//	alpha          Floating point scaling factor, allocated on the host (input)
+//	xD         Tensor descriptor for data input tensor x (input)
+//	x              Data tensor x (input)
+//	beta           Floating point shift factor, allocated on the host (input)
+//	yD         Tensor descriptor for output data tensor y (input)
+//	y              Data tensor y (output)
+//	doBackwards    Boolean to toggle save data in workspace for backwards pass (input)
+//	wspace      Pointer user allocated memory (input)
+func (l *LRND) Forward(h *Handle, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	doBackwards bool,
+	wspace cutil.Mem) error {
+	k := check{op: "(*LRND)Forward()"}
+	xt, yt := k.tensor("xD", xD, x), k.tensor("yD", yD, y)
+	k.sameshape(xt, yt)
+	k.samedtype(xt, yt)
+	err := k.err
+	if err == nil {
+		err = h.b.LRNForward(l, alpha, xD, x, beta, yD, y, doBackwards, wspace)
+	}
+	return withdesc(err, "l", l, "xD", xD, "yD", yD)
+}
+
+//Backward - Execute a LRN backward layer
+//
+//	handle         MIOpen handle (input)
+//	alpha          Floating point scaling factor, allocated on the host (input)
+//	yD          Tensor descriptor for data input tensor y (input)
+//	y              Data tensor y (input)
+//	dyD         Tensor descriptor for data input tensor dy (input)
+//	dy             Data delta tensor dy (input)
+//	xD          Tensor descriptor for input data tensor x (input)
+//	x              Data tensor x (input)
+//	beta           Floating point shift factor, allocated on the host (input)
+//	dxD         Tensor descriptor for output data tensor dx(input)
+//	dx             Data delta tensor x (output)
+//	workSpace      Pointer user allocated memory (input)
+func (l *LRND) Backward(h *Handle, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem) error {
+	k := check{op: "(*LRND)Backward()"}
+	yt, dyt, xt, dxt := k.tensor("yD", yD, y), k.tensor("dyD", dyD, dy), k.tensor("xD", xD, x), k.tensor("dxD", dxD, dx)
+	k.sameshape(xt, yt, dyt, dxt)
+	k.samedtype(xt, yt, dyt, dxt)
+	err := k.err
+	if err == nil {
+		err = h.b.LRNBackward(l, alpha, yD, y, dyD, dy, xD, x, beta, dxD, dx, wspace)
+	}
+	return withdesc(err, "l", l, "yD", yD, "dyD", dyD, "xD", xD, "dxD", dxD)
+}
@@ -1,3 +1,6 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 /*
@@ -9,6 +12,7 @@ import "C"
 //RNND - Recurrent Neural Network descriptor
 type RNND struct {
 	d C.miopenRNNDescriptor_t
+	lifetime
 }
 
 //DataType is used for flags for the tensor layer structs
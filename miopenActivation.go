@@ -1,3 +1,6 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 /*
@@ -7,13 +10,12 @@ package miopen
 import "C"
 import (
 	"runtime"
-
-	"github.com/dereklstinson/cutil"
 )
 
 //ActivationD - Activation descriptor is an object that allows the user to specify the activation mode.
 type ActivationD struct {
 	d C.miopenActivationDescriptor_t
+	lifetime
 }
 
 //CreateActivationDescriptor - Creates the Activation descriptor object
@@ -22,12 +24,21 @@ func CreateActivationDescriptor() (a *ActivationD, err error) {
 	err = Status(C.miopenCreateActivationDescriptor(&a.d)).error("CreateActivationDescriptor")
 
 	runtime.SetFinalizer(a, miopenDestroyActivationDescriptor)
+	a.start("ActivationD")
 
 	return a, err
 }
 func miopenDestroyActivationDescriptor(a *ActivationD) error {
-	return Status(C.miopenDestroyActivationDescriptor(a.d)).error("miopenDestroyActivationDescriptor")
+	return a.end(func() error {
+		return Status(C.miopenDestroyActivationDescriptor(a.d)).error("miopenDestroyActivationDescriptor")
+	})
+}
 
+//Destroy frees the descriptor now instead of when it is garbage collected.  It is safe to call more than
+//once, but the descriptor can't be used after.
+func (a *ActivationD) Destroy() error {
+	runtime.SetFinalizer(a, nil)
+	return miopenDestroyActivationDescriptor(a)
 }
 
 //Set - Sets the activation layer descriptor details
@@ -50,59 +61,6 @@ func (a *ActivationD) Get() (mode ActivationMode, alpha, beta, gamma float64, er
 	return mode, alpha, beta, gamma, err
 }
 
-//Forward - Execute an activation forward layer
-//
-//	h		MIOpen handle (input)
-//	alpha		Floating point scaling factor, allocated on the host (input)
-//	xD		Tensor descriptor for data input tensor x (input)
-//	x		Data tensor x (input)
-//	beta		Floating point shift factor, allocated on the host (input)
-//	yD		Tensor descriptor for output data tensor y (input)
-//	y		Data tensor y (output)
-func (a *ActivationD) Forward(h *Handle, alpha float64,
-	xD *TensorD, x cutil.Mem,
-	beta float64,
-	yD *TensorD, y cutil.Mem) error {
-	dtype, _, _, err := xD.Get()
-	if err != nil {
-		return err
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenActivationForward(h.x, a.d, a1.CPtr(), xD.d, x.Ptr(), b1.CPtr(), yD.d, y.Ptr())).error("(a *Activation)Forward()")
-}
-
-//Backward - Execute a activation backwards layer
-//
-//	h		MIOpen handle (input)
-//	alpha		Floating point scaling factor, allocated on the host (input)
-//	yD		Tensor descriptor for input data tensor y (input)
-//	y		Data tensor y (input)
-//	dyD		Tensor descriptor for input data tensor dy (input)
-//	dy		Data delta tensor dy (input)
-//	xD		Tensor descriptor for data input tensor x (input)
-//	x		Data tensor x (input)
-//	beta		Floating point shift factor, allocated on the host (input)
-//	dxD		Tensor descriptor for data output tensor dx (input)
-//	dx		Output data delta tensor dx (output)
-func (a *ActivationD) Backward(h *Handle, alpha float64,
-	yD *TensorD, y cutil.Mem,
-	dyD *TensorD, dy cutil.Mem,
-	xD *TensorD, x cutil.Mem,
-	beta float64,
-	dxD *TensorD, dx cutil.Mem) error {
-	dtype, _, _, err := xD.Get()
-	if err != nil {
-		return err
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenActivationBackward(h.x, a.d, a1.CPtr(),
-		yD.d, y.Ptr(),
-		dyD.d, dy.Ptr(),
-		xD.d, x.Ptr(), b1.CPtr(), dxD.d, dx.Ptr())).error("(a *Activation)Forward()")
-}
-
 //ActivationMode is used for flags. Flags are set through its methods
 //
 //Activation layer modes
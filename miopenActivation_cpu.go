@@ -0,0 +1,94 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import "runtime"
+
+//ActivationD - Activation descriptor is an object that allows the user to specify the activation mode.
+type ActivationD struct {
+	mode               ActivationMode
+	alpha, beta, gamma float64
+	lifetime
+}
+
+//CreateActivationDescriptor - Creates the Activation descriptor object
+func CreateActivationDescriptor() (a *ActivationD, err error) {
+	a = new(ActivationD)
+	a.start("ActivationD")
+	runtime.SetFinalizer(a, (*ActivationD).Destroy)
+	return a, nil
+}
+
+//Destroy stops tracking the descriptor for leaks.  The cpu build holds no resources outside of go, but it is
+//kept so code written for the rocm build runs unchanged.  It is safe to call more than once.
+func (a *ActivationD) Destroy() error {
+	runtime.SetFinalizer(a, nil)
+	return a.end(nil)
+}
+
+//Set - Sets the activation layer descriptor details
+func (a *ActivationD) Set(mode ActivationMode, alpha, beta, gamma float64) error {
+	a.mode, a.alpha, a.beta, a.gamma = mode, alpha, beta, gamma
+	return nil
+}
+
+//Get - Gets the activation layer descriptor details
+func (a *ActivationD) Get() (mode ActivationMode, alpha, beta, gamma float64, err error) {
+	return a.mode, a.alpha, a.beta, a.gamma, nil
+}
+
+//ActivationMode is used for flags. Flags are set through its methods
+//
+//Activation layer modes
+type ActivationMode int32
+
+//PasThru sets a and returns ActivationMode(PASTHRU) flag
+//
+//No activation, pass through the data
+func (a *ActivationMode) PasThru() ActivationMode { *a = ActivationMode(0); return *a }
+
+//Logistic sets a and returns ActivationMode(LOGISTIC) flag
+//
+// Sigmoid function: 1 / (1 + e^{-x})
+func (a *ActivationMode) Logistic() ActivationMode { *a = ActivationMode(1); return *a }
+
+//Tanh sets a and returns ActivationMode(TANH) flag
+//
+//Tanh activation: beta * tanh(alpha * x)
+func (a *ActivationMode) Tanh() ActivationMode { *a = ActivationMode(2); return *a }
+
+//Relu sets a and returns ActivationMode(RELU) flag
+//
+//Rectified Linear Unit:  max(0, x)
+func (a *ActivationMode) Relu() ActivationMode { *a = ActivationMode(3); return *a }
+
+//SoftRelu sets a and returns ActivationMode(SOFTRELU) flag
+//
+//SoftRelu activation: log(1 + e^x)
+func (a *ActivationMode) SoftRelu() ActivationMode { *a = ActivationMode(4); return *a }
+
+//Abs sets a and returns ActivationMode(ABS) flag
+//
+//Absolute value abs(x)
+func (a *ActivationMode) Abs() ActivationMode { *a = ActivationMode(5); return *a }
+
+//Power sets a and returns ActivationMode(POWER) flag
+//
+//Scaled and shifted power (alpha + beta * x)^{gamma}
+func (a *ActivationMode) Power() ActivationMode { *a = ActivationMode(6); return *a }
+
+//ClippedRelu sets a and returns ActivationMode(CLIPPEDRELU) flag
+//
+//Clipped Rectified Linear Unit: min(alpha, max(0,x))
+func (a *ActivationMode) ClippedRelu() ActivationMode { *a = ActivationMode(7); return *a }
+
+//LeakyRelu sets a and returns ActivationMode(LEAKYRELU) flag
+//
+//Leaky Rectified Linear Unit: alpha * x | x <= 0; x | x > 0
+func (a *ActivationMode) LeakyRelu() ActivationMode { *a = ActivationMode(8); return *a }
+
+//Elu sets a and returns ActivationMode(ELU) flag
+//
+//Exponential Rectified Linear Unit: alpha * (e^{x} - 1) | x <= 0; x | x > 0
+func (a *ActivationMode) Elu() ActivationMode { *a = ActivationMode(9); return *a }
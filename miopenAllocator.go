@@ -0,0 +1,118 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
+package miopen
+
+/*
+#include <stdint.h>
+#include "miopen/miopen.h"
+
+extern void* goMiopenAllocate(uintptr_t ctx, size_t sizeBytes);
+extern void goMiopenDeallocate(uintptr_t ctx, void* memory);
+
+static void* miopenallocate(void* ctx, size_t sizeBytes) {
+	return goMiopenAllocate((uintptr_t)ctx, sizeBytes);
+}
+
+static void miopendeallocate(void* ctx, void* memory) {
+	goMiopenDeallocate((uintptr_t)ctx, memory);
+}
+
+static miopenStatus_t miopensetgoallocator(miopenHandle_t handle, uintptr_t ctx) {
+	if (ctx == 0) {
+		return miopenSetAllocator(handle, NULL, NULL, NULL);
+	}
+	return miopenSetAllocator(handle, miopenallocate, miopendeallocate, (void*)ctx);
+}
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//allocation is an Allocator set on a handle and the memory it handed to MIOpen.  C only gets its id, since
+//go pointers can't be kept by C.
+type allocation struct {
+	a   Allocator
+	mux sync.Mutex
+	mem map[unsafe.Pointer]cutil.Mem
+}
+
+var allocations = struct {
+	mux  sync.Mutex
+	next uintptr
+	m    map[uintptr]*allocation
+}{m: make(map[uintptr]*allocation)}
+
+func addallocation(a Allocator) uintptr {
+	allocations.mux.Lock()
+	defer allocations.mux.Unlock()
+	allocations.next++
+	allocations.m[allocations.next] = &allocation{a: a, mem: make(map[unsafe.Pointer]cutil.Mem)}
+	return allocations.next
+}
+
+func getallocation(id uintptr) *allocation {
+	allocations.mux.Lock()
+	defer allocations.mux.Unlock()
+	return allocations.m[id]
+}
+
+func removeallocation(id uintptr) {
+	allocations.mux.Lock()
+	delete(allocations.m, id)
+	allocations.mux.Unlock()
+}
+
+//export goMiopenAllocate
+func goMiopenAllocate(ctx C.uintptr_t, sizeBytes C.size_t) unsafe.Pointer {
+	a := getallocation(uintptr(ctx))
+	if a == nil {
+		return nil
+	}
+	m, err := a.a.Allocate(uint(sizeBytes))
+	if err != nil || m == nil || m.Ptr() == nil {
+		return nil
+	}
+	a.mux.Lock()
+	a.mem[m.Ptr()] = m
+	a.mux.Unlock()
+	return m.Ptr()
+}
+
+//export goMiopenDeallocate
+func goMiopenDeallocate(ctx C.uintptr_t, memory unsafe.Pointer) {
+	a := getallocation(uintptr(ctx))
+	if a == nil {
+		return
+	}
+	a.mux.Lock()
+	m, ok := a.mem[memory]
+	delete(a.mem, memory)
+	a.mux.Unlock()
+	if ok {
+		a.a.Free(m)
+	}
+}
+
+//SetAllocator makes MIOpen allocate its internal device memory through a.  Passing nil restores MIOpen's allocator.
+//
+//MIOpen has no way to report an error from Free, so it is dropped.  If Allocate fails the operation that
+//needed the memory returns ErrAllocFailed.  Memory still held by MIOpen is given back to the allocator that
+//allocated it, even after SetAllocator is called again.
+func (h *Handle) SetAllocator(a Allocator) error {
+	var id uintptr
+	if a != nil {
+		id = addallocation(a)
+	}
+	err := Status(C.miopensetgoallocator(h.x, C.uintptr_t(id))).error("(*Handle).SetAllocator")
+	if err != nil {
+		removeallocation(id)
+		return err
+	}
+	h.allocs = append(h.allocs, id)
+	return nil
+}
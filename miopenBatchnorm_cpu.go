@@ -0,0 +1,81 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import (
+	"errors"
+)
+
+//BatchNormD is an original to these bindings.  This is to make the batchnorm operation similar to the majority of these bindings.
+type BatchNormD struct {
+	mode BatchNormMode
+	set  bool
+}
+
+//CreateBatchNormDescriptor creates a new BatchNormD
+func CreateBatchNormDescriptor() (*BatchNormD, error) {
+	return new(BatchNormD), nil
+}
+
+//Set sets the values used in the batchnorm descriptor
+func (b *BatchNormD) Set(mode BatchNormMode) error {
+	b.mode = mode
+	b.set = true
+	return nil
+}
+
+//Get gets the values stored in BatchNormMode
+func (b *BatchNormD) Get() (mode BatchNormMode, err error) {
+	if !b.set {
+		return 0, errors.New("BatchNormD not set")
+	}
+	return b.mode, nil
+}
+
+//DeriveBNTensorDescriptor - Derive tensor for gamma and beta (scale and bias) from input tensor descriptor
+//
+//For an input tensor NCHW and spatial mode, the output derived tensor is 1C11, while for
+//per-activation the derived tensor is 1CHW.  Half inputs derive a Float descriptor.
+func (b *BatchNormD) DeriveBNTensorDescriptor(xDesc *TensorD) (bndesc *TensorD, err error) {
+	if !b.set {
+		return nil, errors.New("BatchNormD not set")
+	}
+	if !xDesc.set || len(xDesc.shape) > 5 || len(xDesc.shape) < 4 {
+		return nil, errors.New("dims for descriptor must be 4 or 5")
+	}
+	shape := make([]int32, len(xDesc.shape))
+	var flg BatchNormMode
+	for i := range shape {
+		switch {
+		case i == 0:
+			shape[i] = 1
+		case i == 1 || b.mode == flg.PerActivation():
+			shape[i] = xDesc.shape[i]
+		default:
+			shape[i] = 1
+		}
+	}
+	dtype := xDesc.dtype
+	var dflg DataType
+	if dtype == dflg.Half() {
+		dtype.Float()
+	}
+	bndesc, _ = createtensordescriptor()
+	return bndesc, bndesc.Set(dtype, shape, nil)
+}
+
+//BatchNormMode is used for flags. Flags are set through its methods
+//
+//Batch Normalization layer mode
+type BatchNormMode int32
+
+//PerActivation sets b and returns BatchNormMode(PerActivation) flag
+//
+//Element-wise normalization for fully connected layer
+func (b *BatchNormMode) PerActivation() BatchNormMode { *b = BatchNormMode(0); return *b }
+
+//Spatial sets b and returns BatchNormMode(Spatial) flag
+//
+//Mini-batch spatial normalization for convolutional layers
+func (b *BatchNormMode) Spatial() BatchNormMode { *b = BatchNormMode(1); return *b }
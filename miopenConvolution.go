@@ -1,21 +1,45 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 /*
 #include <miopen/miopen.h>
-
+#include <miopen/version.h>
+
+//Algorithms that older headers don't have get their value from newer headers.  All() leaves them out
+//when the headers are older than their Feature.Since().  The headers are at least 2.0, see miopenVersion.go.
+#if MIOPEN_VERSION_MAJOR > 2 || (MIOPEN_VERSION_MAJOR == 2 && MIOPEN_VERSION_MINOR >= 1)
+#define migo_bwddataimplicitgemm miopenConvolutionBwdDataAlgoImplicitGEMM
+#define migo_bwdweightsimplicitgemm miopenConvolutionBwdWeightsAlgoImplicitGEMM
+#else
+#define migo_bwddataimplicitgemm 5
+#define migo_bwdweightsimplicitgemm 5
+#endif
+
+//the deterministic attribute came with MIOpen 2.16, the shim returns miopenStatusUnsupportedOp for older headers.
+static miopenStatus_t migo_setdeterministic(miopenConvolutionDescriptor_t c, int on) {
+#if MIOPEN_VERSION_MAJOR > 2 || (MIOPEN_VERSION_MAJOR == 2 && MIOPEN_VERSION_MINOR >= 16)
+	return miopenSetConvolutionAttribute(c, MIOPEN_CONVOLUTION_ATTRIB_DETERMINISTIC, on);
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
 */
 import "C"
 import (
+	"fmt"
 	"runtime"
-
-	"github.com/dereklstinson/cutil"
 )
 
 //ConvolutionD - Convolution descriptor is an object that allows the user to specify a layer's padding, stride,
 //and dilation of the convolutional filter. Parameters must all be non-negative.
 type ConvolutionD struct {
-	dims C.int
-	d    C.miopenConvolutionDescriptor_t
+	d             C.miopenConvolutionDescriptor_t
+	adj           []int32
+	deterministic bool //deterministic mirrors the attribute for the cpu backend
+	policy        convpolicy
+	lifetime
 }
 
 //CreateConvolutionDescriptor -  Creates a convolution layer descriptor
@@ -28,13 +52,22 @@ func CreateConvolutionDescriptor() (*ConvolutionD, error) {
 	}
 
 	runtime.SetFinalizer(x, miopenDestroyConvolutionDescriptor)
+	x.start("ConvolutionD")
 
 	return x, nil
 }
 
 func miopenDestroyConvolutionDescriptor(c *ConvolutionD) error {
-	return Status(C.miopenDestroyConvolutionDescriptor(c.d)).error("miopenDestroyConvolutionDescriptor")
+	return c.end(func() error {
+		return Status(C.miopenDestroyConvolutionDescriptor(c.d)).error("miopenDestroyConvolutionDescriptor")
+	})
+}
 
+//Destroy frees the descriptor now instead of when it is garbage collected.  It is safe to call more than
+//once, but the descriptor can't be used after.
+func (c *ConvolutionD) Destroy() error {
+	runtime.SetFinalizer(c, nil)
+	return miopenDestroyConvolutionDescriptor(c)
 }
 
 //Set sets the N-dimensional convolution layer descriptor
@@ -49,27 +82,54 @@ func miopenDestroyConvolutionDescriptor(c *ConvolutionD) error {
 //
 // len(pad) ==len(stride) ==len(dilation)
 func (c *ConvolutionD) Set(pad, stride, dilation []int32, mode ConvolutionMode) error {
-
+	if err := checkconvset("(*ConvolutionD)Set()", pad, stride, dilation); err != nil {
+		return err
+	}
 	cpad := int32Tocint(pad)
 	cstride := int32Tocint(stride)
 	cdilation := int32Tocint(dilation)
-	c.dims = C.int(len(pad))
 
-	return Status(C.miopenInitConvolutionNdDescriptor(c.d, c.dims, &cpad[0], &cstride[0], &cdilation[0], mode.c())).error(" (*ConvolutionD)Set()")
+	err := Status(C.miopenInitConvolutionNdDescriptor(c.d, C.int(len(pad)), &cpad[0], &cstride[0], &cdilation[0], mode.c())).error(" (*ConvolutionD)Set()")
+	if err == nil {
+		c.adj = nil
+	}
+	return err
+}
+
+//spatialdims returns the number of spatial dims of c as MIOpen has it
+func (c *ConvolutionD) spatialdims() (C.int, error) {
+	var dims C.int
+	err := Status(C.miopenGetConvolutionSpatialDim(c.d, &dims)).error("(*ConvolutionD)Get()")
+	if err == nil && dims < 1 {
+		err = statusBadParm.error("(*ConvolutionD)Get(): descriptor not set")
+	}
+	return dims, err
 }
 
 //Get - Retrieves a N-dimensional convolution layer descriptor's details
 //
-//
+//The number of spatial dims is read from MIOpen, so it works for any descriptor and not only for ones
+//set with Set.
 func (c *ConvolutionD) Get() (pad, stride, dilation []int32, mode ConvolutionMode, err error) {
-	padding := make([]C.int, c.dims)
-	striding := make([]C.int, c.dims)
-	dilationing := make([]C.int, c.dims)
+	dims, err := c.spatialdims()
+	if err != nil {
+		return nil, nil, nil, mode, err
+	}
+	padding := make([]C.int, dims)
+	striding := make([]C.int, dims)
+	dilationing := make([]C.int, dims)
 	var actual C.int
-	err = Status(C.miopenGetConvolutionNdDescriptor(c.d, c.dims, &actual, &padding[0], &striding[0], &dilationing[0], mode.cptr())).error("(*ConvolutionD)Get()")
+	err = Status(C.miopenGetConvolutionNdDescriptor(c.d, dims, &actual, &padding[0], &striding[0], &dilationing[0], mode.cptr())).error("(*ConvolutionD)Get()")
 	return cintToint32(padding[:actual]), cintToint32(striding[:actual]), cintToint32(dilationing[:actual]), mode, err
 }
 
+//GetGroupCount returns the number of groups MIOpen has for c
+func (c *ConvolutionD) GetGroupCount() (groupCount int32, err error) {
+	var g C.int
+	err = Status(C.miopenGetConvolutionGroupCount(c.d, &g)).error("GetGroupCount")
+	return int32(g), err
+}
+
 //SetGroupCount -- Set the number of groups to be used in Group/Depthwise convolution
 //
 //Must be called before all computational APIs of group/depthwise convolution, it is preferable to
@@ -83,6 +143,19 @@ func (c *ConvolutionD) SetGroupCount(groupCount int32) error {
 	return Status(C.miopenSetConvolutionGroupCount(c.d, (C.int)(groupCount))).error("SetGroupCount")
 }
 
+//setdeterministic sets the MIOPEN_CONVOLUTION_ATTRIB_DETERMINISTIC attribute of c
+func (c *ConvolutionD) setdeterministic(on bool) error {
+	var v C.int
+	if on {
+		v = 1
+	}
+	err := Status(C.migo_setdeterministic(c.d, v)).error("(*ConvolutionD)setdeterministic()")
+	if err == nil {
+		c.deterministic = on
+	}
+	return err
+}
+
 //SetTransposeOutputPadding - Set the output padding to be used in N-dimensional Transpose convolution
 //
 // This function is optional for initialization of Transpose convolution. If applicable, it must be
@@ -92,9 +165,38 @@ func (c *ConvolutionD) SetGroupCount(groupCount int32) error {
 //
 //	adjA		array of output padding for output data (input)
 func (c *ConvolutionD) SetTransposeOutputPadding(adjA []int32) error {
+	spatial, err := c.spatialdims()
+	if err != nil {
+		return err
+	}
 	dims := (C.int)(len(adjA))
+	if dims != spatial {
+		return statusBadParm.error(fmt.Sprintf("SetTransposeOutputPadding: len(adjA) %d must equal the %d spatial dims of the descriptor", len(adjA), spatial))
+	}
 	cadjA := int32Tocint(adjA)
-	return Status(C.miopenSetTransposeConvNdOutputPadding(c.d, dims, &cadjA[0])).error("SetTransposeOutputPadding")
+	err = Status(C.miopenSetTransposeConvNdOutputPadding(c.d, dims, &cadjA[0])).error("SetTransposeOutputPadding")
+	if err == nil {
+		c.adj = append([]int32(nil), adjA...)
+	}
+	return err
+}
+
+//groupcount returns the group count MIOpen has for c, and outputpadding the output padding last set on c
+//since MIOpen has no call to read it back. They are used by go code that needs the full descriptor, like the
+//cpu backend.
+func (c *ConvolutionD) groupcount() int32 {
+	g, err := c.GetGroupCount()
+	if err != nil || g < 1 {
+		return 1
+	}
+	return g
+}
+func (c *ConvolutionD) outputpadding() []int32 {
+	if c.adj == nil {
+		dims, _ := c.spatialdims()
+		return make([]int32, dims)
+	}
+	return c.adj
 }
 
 //ForwardOutputDim - Get the shape of a resulting N-dimensional tensor from a (N-2)-dimensional convolution
@@ -109,9 +211,19 @@ func (c *ConvolutionD) SetTransposeOutputPadding(adjA []int32) error {
 //	wD		Weight descriptor (input)
 //
 func (c *ConvolutionD) ForwardOutputDim(xD, wD *TensorD) (outputdims []int32, err error) {
+	k := check{op: "(*ConvolutionD)ForwardOutputDim()"}
+	k.desc("xD", xD)
+	k.desc("wD", wD)
+	if k.err != nil {
+		return nil, k.err
+	}
+	spatial, err := c.spatialdims()
+	if err != nil {
+		return nil, err
+	}
 	var dims C.int
-	odims := make([]C.int, c.dims)
-	err = Status(C.miopenGetConvolutionNdForwardOutputDim(c.d, xD.d, wD.d, &dims, &odims[0])).error("SetTransposeOutputPadding")
+	odims := make([]C.int, spatial+2)
+	err = Status(C.miopenGetConvolutionNdForwardOutputDim(c.d, xD.d, wD.d, &dims, &odims[0])).error("(*ConvolutionD)ForwardOutputDim()")
 	outputdims = cintToint32(odims[:dims])
 	return outputdims, err
 }
@@ -149,6 +261,48 @@ func (c *ConvFwdAlgorithm) WinoGrad() ConvFwdAlgorithm {
 	return *c
 }
 
+//ImplicitGEMM sets c and returns ImplicitGEMM flag.  It needs MIOpen 2.0 or newer.
+func (c *ConvFwdAlgorithm) ImplicitGEMM() ConvFwdAlgorithm {
+	*c = (ConvFwdAlgorithm)(C.miopenConvolutionFwdAlgoImplicitGEMM)
+	return *c
+}
+
+//ConvAlgorithm - Used for flags
+//Convolutional algorithm of an immediate mode solution
+type ConvAlgorithm C.miopenConvAlgorithm_t
+
+func (c ConvAlgorithm) c() C.miopenConvAlgorithm_t { return (C.miopenConvAlgorithm_t)(c) }
+
+//GEMM sets c and returns GEMM flag
+func (c *ConvAlgorithm) GEMM() ConvAlgorithm {
+	*c = (ConvAlgorithm)(C.miopenConvolutionAlgoGEMM)
+	return *c
+}
+
+//Direct sets c and returns Direct flag
+func (c *ConvAlgorithm) Direct() ConvAlgorithm {
+	*c = (ConvAlgorithm)(C.miopenConvolutionAlgoDirect)
+	return *c
+}
+
+//FFT sets c and returns FFT flag
+func (c *ConvAlgorithm) FFT() ConvAlgorithm {
+	*c = (ConvAlgorithm)(C.miopenConvolutionAlgoFFT)
+	return *c
+}
+
+//WinoGrad sets c and returns WinoGrad flag
+func (c *ConvAlgorithm) WinoGrad() ConvAlgorithm {
+	*c = (ConvAlgorithm)(C.miopenConvolutionAlgoWinograd)
+	return *c
+}
+
+//ImplicitGEMM sets c and returns ImplicitGEMM flag
+func (c *ConvAlgorithm) ImplicitGEMM() ConvAlgorithm {
+	*c = (ConvAlgorithm)(C.miopenConvolutionAlgoImplicitGEMM)
+	return *c
+}
+
 //ConvBwdWeightsAlgorithm - Used for flags
 //Convolutional algorithm mode for back propagation on weights
 type ConvBwdWeightsAlgorithm C.miopenConvBwdWeightsAlgorithm_t
@@ -159,7 +313,7 @@ func (c ConvBwdWeightsAlgorithm) c() C.miopenConvBwdWeightsAlgorithm_t {
 
 //GEMM sets c and returns GEMM flag
 func (c *ConvBwdWeightsAlgorithm) GEMM() ConvBwdWeightsAlgorithm {
-	*c = (ConvBwdWeightsAlgorithm)(C.miopenConvolutionBwdWeightsAlgoDirect)
+	*c = (ConvBwdWeightsAlgorithm)(C.miopenConvolutionBwdWeightsAlgoGEMM)
 	return *c
 }
 
@@ -175,6 +329,12 @@ func (c *ConvBwdWeightsAlgorithm) WinoGrad() ConvBwdWeightsAlgorithm {
 	return *c
 }
 
+//ImplicitGEMM sets c and returns ImplicitGEMM flag.  It needs MIOpen 2.1 or newer.
+func (c *ConvBwdWeightsAlgorithm) ImplicitGEMM() ConvBwdWeightsAlgorithm {
+	*c = (ConvBwdWeightsAlgorithm)(C.migo_bwdweightsimplicitgemm)
+	return *c
+}
+
 //ConvBwdDataAlgorithm - Used as flags.
 // Convolutional algorithm mode for back propagation on data.
 //
@@ -208,195 +368,17 @@ func (c *ConvBwdDataAlgorithm) WinoGrad() ConvBwdDataAlgorithm {
 	return *c
 }
 
-//Forward - Execute a forward convolution layer
-//
-//Runs the forward convolution layer based on the selected algorithm. The function
-//(*ConvolutionD) FindForwardAlgorithm() must have been executed previously to
-//determine the required memory needed for the workspace and the best convolutional algorithm.
-//
-//If using Group/Depthwise convolution mode, call (*ConvolutionD)SetGroupCount() before running
-//this.
-//
-//	h				MIOpen handle (input)
-//	alpha			Floating point scaling factor, allocated on the host (input)
-//	xD				Tensor descriptor for data input tensor x (input)
-//	x				Data tensor x (input)
-//	wD				Tensor descriptor for weight tensor w (input)
-//	w				Weights tensor w (inputs)
-//	algo			Algorithm selected (inputs)
-//	beta			Floating point shift factor, allocated on the host (input)
-//	yD				Tensor descriptor for output data tensor y (input)
-//	y				Data tensor y (output)
-//	wspace			Pointer to workspace required (input)
-//	wspaceSIB		Size in bytes of the memory determined by the find step (input)
-func (c *ConvolutionD) Forward(
-	h *Handle,
-	alpha float64,
-	xD *TensorD, x cutil.Mem,
-	wD *TensorD, w cutil.Mem,
-	algo *ConvFwdAlgorithm,
-	beta float64,
-	yD *TensorD, y cutil.Mem,
-	wspace cutil.Mem, wspaceSIB uint,
-) error {
-	dtype, _, _, err := xD.Get()
-	if err != nil {
-		return err
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenConvolutionForward(h.x, a1.CPtr(), xD.d, x.Ptr(), wD.d, w.Ptr(), c.d, algo.c(), b1.CPtr(), yD.d, y.Ptr(), wspace.Ptr(), (C.size_t)(wspaceSIB))).error("(c *ConvolutionD)Forward()")
+//TransposeGEMM sets c and returns TransposeGEMM flag.  It is the GEMM algorithm MIOpen picks for
+//the backward data pass of a transpose convolution. It needs MIOpen 2.0 or newer.
+func (c *ConvBwdDataAlgorithm) TransposeGEMM() ConvBwdDataAlgorithm {
+	*c = (ConvBwdDataAlgorithm)(C.miopenTransposeBwdDataAlgoGEMM)
+	return *c
 }
 
-//ForwardBias - Calculate element-wise scale and shift of a tensor via a bias tensor
-//
-//This function applies an element-wise bias to a data tensor from an input bias tensor.
-//	handle         MIOpen handle (input)
-//	alpha          Floating point scaling factor, allocated on the host (input)
-//	bDesc          Tensor descriptor for bias tensor b (input)
-//	b              Bias tensor b (input)
-//	beta           Floating point shift factor, allocated on the host (input)
-//	yDesc          Tensor descriptor for data tensor y (input)
-//	y              Data tensor y (input and output)
-func (c *ConvolutionD) ForwardBias(h *Handle,
-	alpha float64,
-	bD *TensorD, b cutil.Mem,
-	beta float64,
-	yD *TensorD, y cutil.Mem,
-) error {
-	dtype, _, _, err := bD.Get()
-	if err != nil {
-		return err
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenConvolutionForwardBias(
-		h.x,
-		a1.CPtr(),
-		bD.d, b.Ptr(),
-		b1.CPtr(),
-		yD.d, y.Ptr())).error("(c *ConvolutionD)ForwardBias()")
-}
-
-//BackwardData -Execute a backward data convolution layer
-// Runs the backward data convolution layer based on the selected algorithm. The function
-// (*ConvolutionD)GetBwdDataWorkspaceSize() must have been executed previously to
-// determine the required memory needed for the workspace and the (*ConvolutionD) FindBwdDataAlgo() for the best convolutional algorithm.
-//
-//If using Group/Depthwise convolution mode, call  (*ConvolutionD)SetGroupCount() before running this.
-//
-//	h		MIOpen handle (input)
-//	alpha		Floating point scaling factor, allocated on the host (input)
-//	dyD		Tensor descriptor for data input tensor dy (input)
-//	dy		Data delta tensor dy (input)
-//	wD		Tensor descriptor for weight tensor w (input)
-//	w		Weights tensor w (input)
-//	algo		Algorithm selected (input)
-//	beta		Floating point shift factor, allocated on the host (input)
-//	dxD		Tensor descriptor for output data tensor dx (input)
-//	dx		Data delta tensor dx (output)
-//	wspace		Pointer to workspace required for the search (input)
-//	wspaceSIB		Size in bytes of the memory needed for find (input)
-func (c *ConvolutionD) BackwardData(h *Handle,
-	alpha float64,
-	dyD *TensorD, dy cutil.Mem,
-	wD *TensorD, w cutil.Mem,
-	algo ConvBwdDataAlgorithm,
-	beta float64,
-	dxD *TensorD, dx cutil.Mem,
-	wspace cutil.Mem, wspaceSIB uint) error {
-	dtype, _, _, err := dyD.Get()
-	if err != nil {
-		return err
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenConvolutionBackwardData(h.x,
-		a1.CPtr(),
-		dyD.d, dy.Ptr(),
-		wD.d, w.Ptr(),
-		c.d,
-		algo.c(),
-		b1.CPtr(),
-		dxD.d, dx.Ptr(),
-		wspace.Ptr(), (C.size_t)(wspaceSIB))).error("(c *ConvolutionD)BackwardWeights()")
-}
-
-//BackwardWeights - Execute a backward weights convolution layer
-//
-//Runs the backward weights convolution layer based on the selected algorithm. The function
-//(*ConvolutionD)GetBwdWeightsWorkspaceSize() must have been executed previously to determine the required memory needed
-//for the workspace and the (*ConvolutionD) FindBwdWeightsAlgorithm() for the best convolutional algorithm.
-//
-//If using Group/Depthwise convolution mode, call (*ConvolutionD)SetGroupCount() before running this.
-//
-//handle		MIOpen handle (input)
-//alpha		Floating point scaling factor, allocated on the host (input)
-//dyD		Tensor descriptor for data tensor dy (input)
-//dy		Data delta tensor dy (input)
-//xD		Tensor descriptor for data tensor x (input)
-//x		Data tensor x (input)
-//algo		Algorithm selected (input)
-//beta		Floating point shift factor, allocated on the host (input)
-//dwD		Tensor descriptor for weight tensor dw (input)
-//dw		Weights delta tensor dw (output)
-//wspace		Pointer to workspace required for the search (input)
-//wspaceSIB		Size in bytes of the memory needed for find (input)
-//
-func (c *ConvolutionD) BackwardWeights(h *Handle,
-	alpha float64,
-	dyD *TensorD, dy cutil.Mem,
-	xD *TensorD, x cutil.Mem,
-	algo ConvBwdWeightsAlgorithm,
-	beta float64,
-	dwD *TensorD, dw cutil.Mem,
-	wspace cutil.Mem, wspaceSIB uint) error {
-	dtype, _, _, err := xD.Get()
-	if err != nil {
-		return err
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenConvolutionBackwardWeights(h.x,
-		a1.CPtr(),
-		dyD.d, dy.Ptr(),
-		xD.d, x.Ptr(),
-		c.d,
-		algo.c(),
-		b1.CPtr(),
-		dwD.d, dw.Ptr(),
-		wspace.Ptr(), (C.size_t)(wspaceSIB))).error("(c *ConvolutionD)BackwardWeights()")
-}
-
-//BackwardBias - Calculates the gradient with respect to the bias.
-//
-//Compute the convolution backwards gradient with respect to the bias tensor.
-//
-//	h		MIOpen handle (input)
-//	alpha		Floating point scaling factor, allocated on the host (input)
-//	dyD		Tensor descriptor for data input tensor dy (input)
-//	dy		Data delta tensor dy (input)
-//	beta		point shift factor, allocated on the host (input)
-//	dbD		Tensor descriptor for input bias tensor db (input)
-//	db		Bias delta tensor db (output)
-func (c *ConvolutionD) BackwardBias(
-	h *Handle,
-	alpha float64,
-	dyD *TensorD, dy cutil.Mem,
-	beta float64,
-	dbD *TensorD, db cutil.Mem) error {
-	dtype, _, _, err := dyD.Get()
-	if err != nil {
-		return err
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenConvolutionBackwardBias(
-		h.x,
-		a1.CPtr(),
-		dyD.d, dy.Ptr(),
-		b1.CPtr(),
-		dbD.d, db.Ptr())).error("(c *ConvolutionD)BackwardBias()")
+//ImplicitGEMM sets c and returns ImplicitGEMM flag.  It needs MIOpen 2.1 or newer.
+func (c *ConvBwdDataAlgorithm) ImplicitGEMM() ConvBwdDataAlgorithm {
+	*c = (ConvBwdDataAlgorithm)(C.migo_bwddataimplicitgemm)
+	return *c
 }
 
 //ConvolutionMode is the type to describe the convolution mode flags
@@ -0,0 +1,52 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+//ConvFwdAlgoPerf holds the performance of a forward convolution algorithm
+type ConvFwdAlgoPerf struct {
+	algo   ConvFwdAlgorithm
+	time   float32
+	memory uint
+}
+
+func newConvFwdAlgoPerf(algo ConvFwdAlgorithm, time float32, wspaceSIB uint) ConvFwdAlgoPerf {
+	return ConvFwdAlgoPerf{algo: algo, time: time, memory: wspaceSIB}
+}
+
+//Get gets the values of ConvFowdAlgoPerf
+func (c *ConvFwdAlgoPerf) Get() (algo ConvFwdAlgorithm, time float32, wspaceSIB uint) {
+	return c.algo, c.time, c.memory
+}
+
+//ConvBwdDataAlgoPerf holds the performance of a backward data convolution algorithm
+type ConvBwdDataAlgoPerf struct {
+	algo   ConvBwdDataAlgorithm
+	time   float32
+	memory uint
+}
+
+func newConvBwdDataAlgoPerf(algo ConvBwdDataAlgorithm, time float32, wspaceSIB uint) ConvBwdDataAlgoPerf {
+	return ConvBwdDataAlgoPerf{algo: algo, time: time, memory: wspaceSIB}
+}
+
+//Get gets the values of ConvBwdDataAlgoPerf
+func (c *ConvBwdDataAlgoPerf) Get() (algo ConvBwdDataAlgorithm, time float32, wspaceSIB uint) {
+	return c.algo, c.time, c.memory
+}
+
+//ConvBwdWeightAlgoPerf holds the performance of a backward weights convolution algorithm
+type ConvBwdWeightAlgoPerf struct {
+	algo   ConvBwdWeightsAlgorithm
+	time   float32
+	memory uint
+}
+
+func newConvBwdWeightAlgoPerf(algo ConvBwdWeightsAlgorithm, time float32, wspaceSIB uint) ConvBwdWeightAlgoPerf {
+	return ConvBwdWeightAlgoPerf{algo: algo, time: time, memory: wspaceSIB}
+}
+
+//Get gets the values of ConvBwdWeightAlgoPerf
+func (c *ConvBwdWeightAlgoPerf) Get() (algo ConvBwdWeightsAlgorithm, time float32, wspaceSIB uint) {
+	return c.algo, c.time, c.memory
+}
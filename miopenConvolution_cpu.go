@@ -0,0 +1,231 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import "runtime"
+
+//ConvolutionD - Convolution descriptor is an object that allows the user to specify a layer's padding, stride,
+//and dilation of the convolutional filter. Parameters must all be non-negative.
+type ConvolutionD struct {
+	mode                  ConvolutionMode
+	pad, stride, dilation []int32
+	adj                   []int32
+	groups                int32
+	deterministic         bool
+	policy                convpolicy
+	lifetime
+}
+
+//CreateConvolutionDescriptor -  Creates a convolution layer descriptor
+func CreateConvolutionDescriptor() (*ConvolutionD, error) {
+	c := &ConvolutionD{groups: 1}
+	c.start("ConvolutionD")
+	runtime.SetFinalizer(c, (*ConvolutionD).Destroy)
+	return c, nil
+}
+
+//Destroy stops tracking the descriptor for leaks.  The cpu build holds no resources outside of go, but it is
+//kept so code written for the rocm build runs unchanged.  It is safe to call more than once.
+func (c *ConvolutionD) Destroy() error {
+	runtime.SetFinalizer(c, nil)
+	return c.end(nil)
+}
+
+//Set sets the N-dimensional convolution layer descriptor
+//
+// len(pad) ==len(stride) ==len(dilation)
+func (c *ConvolutionD) Set(pad, stride, dilation []int32, mode ConvolutionMode) error {
+	if err := checkconvset("(*ConvolutionD)Set()", pad, stride, dilation); err != nil {
+		return err
+	}
+	c.pad = append([]int32(nil), pad...)
+	c.stride = append([]int32(nil), stride...)
+	c.dilation = append([]int32(nil), dilation...)
+	c.mode = mode
+	c.adj = make([]int32, len(pad))
+	return nil
+}
+
+//Get - Retrieves a N-dimensional convolution layer descriptor's details
+func (c *ConvolutionD) Get() (pad, stride, dilation []int32, mode ConvolutionMode, err error) {
+	if c.pad == nil {
+		return nil, nil, nil, mode, statusBadParm.error("(*ConvolutionD)Get(): descriptor not set")
+	}
+	return append([]int32(nil), c.pad...), append([]int32(nil), c.stride...), append([]int32(nil), c.dilation...), c.mode, nil
+}
+
+//SetGroupCount -- Set the number of groups to be used in Group/Depthwise convolution
+func (c *ConvolutionD) SetGroupCount(groupCount int32) error {
+	if groupCount < 1 {
+		return statusBadParm.error("SetGroupCount")
+	}
+	c.groups = groupCount
+	return nil
+}
+
+//setdeterministic sets the deterministic attribute of c, the cpu backend then rejects the algorithms that
+//aren't deterministic
+func (c *ConvolutionD) setdeterministic(on bool) error {
+	c.deterministic = on
+	return nil
+}
+
+//GetGroupCount returns the number of groups of c
+func (c *ConvolutionD) GetGroupCount() (groupCount int32, err error) {
+	return c.groups, nil
+}
+
+//SetTransposeOutputPadding - Set the output padding to be used in N-dimensional Transpose convolution
+func (c *ConvolutionD) SetTransposeOutputPadding(adjA []int32) error {
+	if len(adjA) == 0 || len(adjA) != len(c.pad) {
+		return statusBadParm.error("SetTransposeOutputPadding: len(adjA) must equal the spatial dims of the descriptor")
+	}
+	c.adj = append([]int32(nil), adjA...)
+	return nil
+}
+
+//ForwardOutputDim - Get the shape of a resulting N-dimensional tensor from a (N-2)-dimensional convolution
+func (c *ConvolutionD) ForwardOutputDim(xD, wD *TensorD) (outputdims []int32, err error) {
+	k := check{op: "(*ConvolutionD)ForwardOutputDim()"}
+	xt, wt := k.desc("xD", xD), k.desc("wD", wD)
+	if k.err != nil {
+		return nil, k.err
+	}
+	g, err := c.Geometry()
+	if err != nil {
+		return nil, err
+	}
+	return g.OutputDim(xt.shape, wt.shape)
+}
+
+func (c *ConvolutionD) groupcount() int32      { return c.groups }
+func (c *ConvolutionD) outputpadding() []int32 { return c.adj }
+
+//ConvFwdAlgorithm - Used as flags.
+//Convolutional algorithm mode for forward propagation.
+type ConvFwdAlgorithm int32
+
+//GEMM sets c and returns GEMM flag
+func (c *ConvFwdAlgorithm) GEMM() ConvFwdAlgorithm { *c = ConvFwdAlgorithm(0); return *c }
+
+//Direct sets c and returns Direct flag
+func (c *ConvFwdAlgorithm) Direct() ConvFwdAlgorithm { *c = ConvFwdAlgorithm(1); return *c }
+
+//FFT sets c and returns FFT flag
+func (c *ConvFwdAlgorithm) FFT() ConvFwdAlgorithm { *c = ConvFwdAlgorithm(2); return *c }
+
+//WinoGrad sets c and returns WinoGrad flag
+func (c *ConvFwdAlgorithm) WinoGrad() ConvFwdAlgorithm { *c = ConvFwdAlgorithm(3); return *c }
+
+//ImplicitGEMM sets c and returns ImplicitGEMM flag
+func (c *ConvFwdAlgorithm) ImplicitGEMM() ConvFwdAlgorithm { *c = ConvFwdAlgorithm(5); return *c }
+
+//ConvAlgorithm - Used for flags
+//Convolutional algorithm of an immediate mode solution
+type ConvAlgorithm int32
+
+//GEMM sets c and returns GEMM flag
+func (c *ConvAlgorithm) GEMM() ConvAlgorithm { *c = ConvAlgorithm(0); return *c }
+
+//Direct sets c and returns Direct flag
+func (c *ConvAlgorithm) Direct() ConvAlgorithm { *c = ConvAlgorithm(1); return *c }
+
+//FFT sets c and returns FFT flag
+func (c *ConvAlgorithm) FFT() ConvAlgorithm { *c = ConvAlgorithm(2); return *c }
+
+//WinoGrad sets c and returns WinoGrad flag
+func (c *ConvAlgorithm) WinoGrad() ConvAlgorithm { *c = ConvAlgorithm(3); return *c }
+
+//ImplicitGEMM sets c and returns ImplicitGEMM flag
+func (c *ConvAlgorithm) ImplicitGEMM() ConvAlgorithm { *c = ConvAlgorithm(5); return *c }
+
+//ConvBwdWeightsAlgorithm - Used for flags
+//Convolutional algorithm mode for back propagation on weights
+type ConvBwdWeightsAlgorithm int32
+
+//GEMM sets c and returns GEMM flag
+func (c *ConvBwdWeightsAlgorithm) GEMM() ConvBwdWeightsAlgorithm {
+	*c = ConvBwdWeightsAlgorithm(0)
+	return *c
+}
+
+//Direct sets c and returns Direct flag
+func (c *ConvBwdWeightsAlgorithm) Direct() ConvBwdWeightsAlgorithm {
+	*c = ConvBwdWeightsAlgorithm(1)
+	return *c
+}
+
+//WinoGrad sets c and returns WinoGrad flag
+func (c *ConvBwdWeightsAlgorithm) WinoGrad() ConvBwdWeightsAlgorithm {
+	*c = ConvBwdWeightsAlgorithm(3)
+	return *c
+}
+
+//ImplicitGEMM sets c and returns ImplicitGEMM flag
+func (c *ConvBwdWeightsAlgorithm) ImplicitGEMM() ConvBwdWeightsAlgorithm {
+	*c = ConvBwdWeightsAlgorithm(5)
+	return *c
+}
+
+//ConvBwdDataAlgorithm - Used as flags.
+// Convolutional algorithm mode for back propagation on data.
+type ConvBwdDataAlgorithm int32
+
+//GEMM sets c and returns GEMM flag
+func (c *ConvBwdDataAlgorithm) GEMM() ConvBwdDataAlgorithm { *c = ConvBwdDataAlgorithm(0); return *c }
+
+//Direct sets c and returns Direct flag
+func (c *ConvBwdDataAlgorithm) Direct() ConvBwdDataAlgorithm { *c = ConvBwdDataAlgorithm(1); return *c }
+
+//FFT sets c and returns FFT flag
+func (c *ConvBwdDataAlgorithm) FFT() ConvBwdDataAlgorithm { *c = ConvBwdDataAlgorithm(2); return *c }
+
+//WinoGrad sets c and returns WinoGrad flag
+func (c *ConvBwdDataAlgorithm) WinoGrad() ConvBwdDataAlgorithm {
+	*c = ConvBwdDataAlgorithm(3)
+	return *c
+}
+
+//TransposeGEMM sets c and returns TransposeGEMM flag
+func (c *ConvBwdDataAlgorithm) TransposeGEMM() ConvBwdDataAlgorithm {
+	*c = ConvBwdDataAlgorithm(4)
+	return *c
+}
+
+//ImplicitGEMM sets c and returns ImplicitGEMM flag
+func (c *ConvBwdDataAlgorithm) ImplicitGEMM() ConvBwdDataAlgorithm {
+	*c = ConvBwdDataAlgorithm(5)
+	return *c
+}
+
+//ConvolutionMode is the type to describe the convolution mode flags
+type ConvolutionMode int32
+
+//Convolution sets and returns value of c to ConvolutionMode(Convolution)
+//
+//Cross-Correlation convolution
+func (c *ConvolutionMode) Convolution() ConvolutionMode { *c = ConvolutionMode(0); return *c }
+
+// Transpose sets and returns value of c to  ConvolutionMode(Transpose)
+//
+//Transpose convolutions -- deconvolution
+func (c *ConvolutionMode) Transpose() ConvolutionMode { *c = ConvolutionMode(1); return *c }
+
+//PaddingMode is used for flags for the PaddingMode. Flags are set through its methods
+type PaddingMode int32
+
+//Default sets p and returns PaddingMode(Default) flag
+//
+//MIOPEN Default Padding
+func (p *PaddingMode) Default() PaddingMode { *p = PaddingMode(0); return *p }
+
+//Same sets p and returns PaddingMode(Same) flag
+//
+// Tensorflow SAME Padding
+func (p *PaddingMode) Same() PaddingMode { *p = PaddingMode(1); return *p }
+
+//Valid sets p and returns PaddingMode(Valid) flag
+//
+//MIOPEN VALID Padding
+func (p *PaddingMode) Valid() PaddingMode { *p = PaddingMode(2); return *p }
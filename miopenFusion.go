@@ -1,3 +1,6 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 //#include <miopen/miopen.h>
@@ -13,6 +16,7 @@ import (
 type FusionPlanD struct {
 	d     C.miopenFusionPlanDescriptor_t
 	dtype DataType
+	lifetime
 }
 
 //FusionOpD - Fusion Operator Descriptor contains the meta-data associated with an operator
@@ -34,10 +38,20 @@ func CreateFusionPlan(direction FusionDirection, inputD *TensorD) (fpD *FusionPl
 	err = Status(C.miopenCreateFusionPlan(&fpD.d, direction.c(), inputD.d)).error("CreateFusionPlan")
 
 	runtime.SetFinalizer(fpD, miopenDestroyFusionPlan)
+	fpD.start("FusionPlanD")
 	return fpD, err
 }
 func miopenDestroyFusionPlan(f *FusionPlanD) error {
-	return Status(C.miopenDestroyFusionPlan(f.d)).error("miopenDestroyFusionPlan")
+	return f.end(func() error {
+		return Status(C.miopenDestroyFusionPlan(f.d)).error("miopenDestroyFusionPlan")
+	})
+}
+
+//Destroy frees the fusion plan now instead of when it is garbage collected.  It is safe to call more than
+//once, but the fusion plan can't be used after.
+func (f *FusionPlanD) Destroy() error {
+	runtime.SetFinalizer(f, nil)
+	return miopenDestroyFusionPlan(f)
 }
 
 //Compile - Compiles the fusion plan
@@ -179,6 +193,7 @@ func (f *FusionPlanD) CreateBatchNormBackward(bnD *BatchNormD) (bnOp *FusionOpD,
 //OperatorArgs is an operator argument opbject
 type OperatorArgs struct {
 	args C.miopenOperatorArgs_t
+	lifetime
 }
 
 //CreateOperatorArgs - Creates an operator argument object
@@ -190,11 +205,21 @@ func CreateOperatorArgs() (args *OperatorArgs, err error) {
 		return nil, err
 	}
 	runtime.SetFinalizer(args, miopenDestroyOperatorArgs)
+	args.start("OperatorArgs")
 	return args, err
 }
 
 func miopenDestroyOperatorArgs(args *OperatorArgs) error {
-	return Status(C.miopenDestroyOperatorArgs(args.args)).error("miopenDestroyOperatorArgs")
+	return args.end(func() error {
+		return Status(C.miopenDestroyOperatorArgs(args.args)).error("miopenDestroyOperatorArgs")
+	})
+}
+
+//Destroy frees the operator args now instead of when it is garbage collected.  It is safe to call more than
+//once, but the operator args can't be used after.
+func (args *OperatorArgs) Destroy() error {
+	runtime.SetFinalizer(args, nil)
+	return miopenDestroyOperatorArgs(args)
 }
 
 //SetConvForward - Sets the arguments for forward convolution op
@@ -1,37 +1,127 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 //#include "miopen/miopen.h"
 //#include <hip/hip_runtime_api.h>
 import "C"
-import "runtime"
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
 
 //Handle handles the functions for miopen
 type Handle struct {
-	x C.miopenHandle_t
+	x      C.miopenHandle_t
+	b      Backend
+	allocs []uintptr  //allocs are the ids of the allocators set with SetAllocator
+	wsmux  sync.Mutex //wsmux guards ws and ownsws
+	ws     *Workspace
+	ownsws bool //ownsws is true if ws was made by Workspace() and has to be freed with h
+	fc     *FindCache
+	device string //device is the name of the HIP device that was current when the handle was made
+
+	deterministic bool
+	lifetime
 }
 
-func init() {
-	x := C.hipInit(0)
-	if x != 0 {
-		panic(x)
-	}
+var hip struct {
+	once sync.Once
+	err  error
 }
 
-//CreateHandle creates a handle.
-func CreateHandle() *Handle {
+//hipinit initializes HIP the first time a handle is made, so importing the package doesn't fail on
+//machines without a GPU.
+func hipinit() error {
+	hip.once.Do(func() {
+		if x := C.hipInit(0); x != 0 {
+			hip.err = &Error{Status: statusNotInitialized, Op: fmt.Sprintf("hipInit: hipError_t(%d)", int(x))}
+		}
+	})
+	return hip.err
+}
+
+//NewHandle creates a handle.  HIP is initialized the first time it is called.
+//
+//The returned error wraps ErrNotInitialized if HIP can't be initialized, e.g. when there is no GPU.
+func NewHandle(opts ...HandleOption) (*Handle, error) {
+	if err := hipinit(); err != nil {
+		return nil, err
+	}
+	cfg := handleoptions(opts)
 	handle := new(Handle)
-	err := Status(C.miopenCreate(&handle.x)).error("NewHandle")
+	var err error
+	if cfg.stream != nil {
+		err = Status(C.miopenCreateWithStream(&handle.x, C.miopenAcceleratorQueue_t(cfg.stream.Ptr()))).error("NewHandle")
+	} else {
+		err = Status(C.miopenCreate(&handle.x)).error("NewHandle")
+	}
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
+	handle.device = currentdevice()
+	handle.SetBackend(cfg.backend)
 	runtime.SetFinalizer(handle, miopenDestroy)
+	handle.start("Handle")
+
+	return handle, nil
+}
 
+//CreateHandle creates a handle.  It panics if the handle can't be created, use NewHandle to get an error instead.
+func CreateHandle() *Handle {
+	handle, err := NewHandle()
+	if err != nil {
+		panic(err)
+	}
 	return handle
 }
 
+//devicename returns the name of the HIP device of h, which FindCache and the Auto functions use to keep the
+//results of different GPUs apart.
+func (h *Handle) devicename() string {
+	return h.device
+}
+
+//currentdevice returns the name of the current HIP device
+func currentdevice() string {
+	var dev C.int
+	if C.hipGetDevice(&dev) != 0 {
+		return "unknown"
+	}
+	var name [256]C.char
+	if C.hipDeviceGetName(&name[0], C.int(len(name)), dev) != 0 {
+		return fmt.Sprintf("device%d", int(dev))
+	}
+	return C.GoString(&name[0])
+}
+
+func (h *Handle) defaultbackend() Backend {
+	return &rocmBackend{x: h.x}
+}
+
 func miopenDestroy(h *Handle) error {
-	return Status(C.miopenDestroy(h.x)).error("(*Handle).Destroy")
+	return h.end(func() error {
+		err := Status(C.miopenDestroy(h.x)).error("(*Handle).Destroy")
+		h.wsmux.Lock()
+		if h.ownsws {
+			h.ws.Free()
+		}
+		h.wsmux.Unlock()
+		for _, id := range h.allocs {
+			removeallocation(id)
+		}
+		return err
+	})
+}
+
+//Destroy frees the handle now instead of when it is garbage collected.  It is safe to call more than
+//once, but the handle can't be used after.
+func (h *Handle) Destroy() error {
+	runtime.SetFinalizer(h, nil)
+	return miopenDestroy(h)
 }
 
 //SetStream passes a stream to sent in the cuda handle
@@ -56,6 +146,9 @@ func (h *Handle) GetStream() (Streamer, error) {
 //In order to use multi-threaded profiling, create an MIOpen handle for each
 //concurrent thread.
 func (h *Handle) GetKernelTime() (time float32, err error) {
+	if p, ok := h.b.(profiler); ok {
+		return p.GetKernelTime()
+	}
 	err = Status(C.miopenGetKernelTime(h.x, (*C.float)(&time))).error("GetKernelTime")
 	return time, err
 }
@@ -64,16 +157,9 @@ func (h *Handle) GetKernelTime() (time float32, err error) {
 //
 //Enable or disable kernel profiling. This profiling is only for kernel time.
 func (h *Handle) EnableProfiling(enable bool) (err error) {
+	if p, ok := h.b.(profiler); ok {
+		return p.EnableProfiling(enable)
+	}
 	return Status(C.miopenEnableProfiling(h.x, (C.bool)(enable))).error("EnableProfiling")
 
 }
-
-/*TODO:
-MIOPEN_EXPORT miopenStatus_t miopenSetAllocator(miopenHandle_t handle,
-                                                miopenAllocatorFunction allocator,
-                                                miopenDeallocatorFunction deallocator,
-                                                void* allocatorContext);
-
-
-
-*/
@@ -0,0 +1,91 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import (
+	"runtime"
+	"sync"
+)
+
+//Handle handles the functions for miopen
+//
+//In the cpu build every operation runs synchronously on host memory. cutil.Mem passed to
+//operations must point to host memory.
+type Handle struct {
+	s      Streamer
+	b      Backend
+	a      Allocator
+	wsmux  sync.Mutex
+	ws     *Workspace
+	ownsws bool
+	fc     *FindCache
+
+	deterministic bool
+	lifetime
+}
+
+//NewHandle creates a handle.  WithStream only stores the stream on the handle.
+func NewHandle(opts ...HandleOption) (*Handle, error) {
+	cfg := handleoptions(opts)
+	h := &Handle{s: cfg.stream}
+	h.SetBackend(cfg.backend)
+	h.start("Handle")
+	runtime.SetFinalizer(h, (*Handle).Destroy)
+	return h, nil
+}
+
+//CreateHandle creates a handle.
+func CreateHandle() *Handle {
+	h, _ := NewHandle()
+	return h
+}
+
+//Destroy stops tracking the handle for leaks.  The cpu build holds no resources outside of go, but it is
+//kept so code written for the rocm build runs unchanged.  It is safe to call more than once.
+func (h *Handle) Destroy() error {
+	runtime.SetFinalizer(h, nil)
+	return h.end(nil)
+}
+
+func (h *Handle) devicename() string {
+	return "cpu"
+}
+
+func (h *Handle) defaultbackend() Backend {
+	return NewCPUBackend()
+}
+
+//SetStream stores the stream on the handle. The cpu backend does not use it.
+func (h *Handle) SetStream(s Streamer) error {
+	h.s = s
+	return nil
+}
+
+//GetStream will return the stream that was passed by SetStream
+func (h *Handle) GetStream() (Streamer, error) {
+	return h.s, nil
+}
+
+//GetKernelTime - returns the time in ms of the last operation if profiling is enabled.
+func (h *Handle) GetKernelTime() (time float32, err error) {
+	if p, ok := h.b.(profiler); ok {
+		return p.GetKernelTime()
+	}
+	return 0, statusNotImplemented.error("GetKernelTime")
+}
+
+//EnableProfiling - Enables profiling to retrieve kernel time
+func (h *Handle) EnableProfiling(enable bool) (err error) {
+	if p, ok := h.b.(profiler); ok {
+		return p.EnableProfiling(enable)
+	}
+	return statusNotImplemented.error("EnableProfiling")
+}
+
+//SetAllocator stores a on the handle.  The cpu backend works on host memory and never allocates device memory,
+//so a isn't called.
+func (h *Handle) SetAllocator(a Allocator) error {
+	h.a = a
+	return nil
+}
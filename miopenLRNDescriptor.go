@@ -1,3 +1,6 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 /*
@@ -6,10 +9,7 @@ package miopen
 */
 import "C"
 import (
-	"errors"
 	"runtime"
-
-	"github.com/dereklstinson/cutil"
 )
 
 //LRND - LRN descriptor is an object that allows the user to specify the LRN mode, the number of elements
@@ -17,6 +17,7 @@ import (
 type LRND struct {
 	d    C.miopenLRNDescriptor_t
 	gogc bool
+	lifetime
 }
 
 //CreateLRNDescriptor - Creates a local response normalization (LRN) layer descriptor
@@ -26,12 +27,22 @@ func CreateLRNDescriptor() (lrnDesc *LRND, err error) {
 	err = Status(C.miopenCreateLRNDescriptor(&lrnDesc.d)).error("CreateLRNDescriptor")
 
 	runtime.SetFinalizer(lrnDesc, miopenDestroyLRNDescriptor)
+	lrnDesc.start("LRND")
 
 	return lrnDesc, err
 }
 
 func miopenDestroyLRNDescriptor(l *LRND) error {
-	return Status(C.miopenDestroyLRNDescriptor(l.d)).error("miopenDestroyLRNDescriptor")
+	return l.end(func() error {
+		return Status(C.miopenDestroyLRNDescriptor(l.d)).error("miopenDestroyLRNDescriptor")
+	})
+}
+
+//Destroy frees the descriptor now instead of when it is garbage collected.  It is safe to call more than
+//once, but the descriptor can't be used after.
+func (l *LRND) Destroy() error {
+	runtime.SetFinalizer(l, nil)
+	return miopenDestroyLRNDescriptor(l)
 }
 
 //Set - Sets a LRN layer descriptor details
@@ -70,67 +81,6 @@ func (l *LRND) GetWorkSpaceSize(yD *TensorD) (wspaceSIB uint, err error) {
 	return wspaceSIB, err
 }
 
-//Forward - Execute a LRN forward layer
-//
-//Runs the forward layer normalization in the forward direction. If doBackwards == 0, then
-//set workSpace = nullptr. However, if the user wishes to execute backwards,
-//then they must set doBackwards = 1 in miopenLRNForward().
-//
-//	h         MIOpen handle (input)
-//	alpha          Floating point scaling factor, allocated on the host (input)
-//	xD         Tensor descriptor for data input tensor x (input)
-//	x              Data tensor x (input)
-//	beta           Floating point shift factor, allocated on the host (input)
-//	yD         Tensor descriptor for output data tensor y (input)
-//	y              Data tensor y (output)
-//	doBackwards    Boolean to toggle save data in workspace for backwards pass (input)
-//	wspace      Pointer user allocated memory (input)
-func (l *LRND) Forward(h *Handle, alpha float64,
-	xD *TensorD, x cutil.Mem,
-	beta float64,
-	yD *TensorD, y cutil.Mem,
-	doBackwards bool,
-	wspace cutil.Mem) error {
-	dtype, _, _, err := xD.Get()
-	if err != nil {
-		return errors.New(err.Error() + " in (*Pooling)Backward()")
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenLRNForward(h.x, l.d, a1.CPtr(), xD.d, x.Ptr(), b1.CPtr(), yD.d, y.Ptr(), (C.bool)(doBackwards), wspace.Ptr())).error(" (l *LRND)Forward()")
-
-}
-
-//Backward - Execute a LRN backward layer
-//
-//	handle         MIOpen handle (input)
-//	alpha          Floating point scaling factor, allocated on the host (input)
-//	yD          Tensor descriptor for data input tensor y (input)
-//	y              Data tensor y (input)
-//	dyD         Tensor descriptor for data input tensor dy (input)
-//	dy             Data delta tensor dy (input)
-//	xD          Tensor descriptor for input data tensor x (input)
-//	x              Data tensor x (input)
-//	beta           Floating point shift factor, allocated on the host (input)
-//	dxD         Tensor descriptor for output data tensor dx(input)
-//	dx             Data delta tensor x (output)
-//	workSpace      Pointer user allocated memory (input)
-func (l *LRND) Backward(h *Handle, alpha float64,
-	yD *TensorD, y cutil.Mem,
-	dyD *TensorD, dy cutil.Mem,
-	xD *TensorD, x cutil.Mem,
-	beta float64,
-	dxD *TensorD, dx cutil.Mem,
-	wspace cutil.Mem) error {
-	dtype, _, _, err := yD.Get()
-	if err != nil {
-		return errors.New(err.Error() + " in (*Pooling)Backward()")
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenLRNBackward(h.x, l.d, a1.CPtr(), yD.d, y.Ptr(), dyD.d, dy.Ptr(), xD.d, x.Ptr(), b1.CPtr(), dxD.d, dx.Ptr(), wspace.Ptr())).error("(l *LRND)Backward()")
-}
-
 //LRNMode is used for flags for the LRNMode. Flags are set through its methods
 //
 //Local Response Normalization layer mode
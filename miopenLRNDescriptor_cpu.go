@@ -0,0 +1,66 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import "runtime"
+
+//LRND - LRN descriptor is an object that allows the user to specify the LRN mode, the number of elements
+//in the normalization window, and the LRN k-parameter.
+type LRND struct {
+	mode           LRNMode
+	n              uint32
+	alpha, beta, k float64
+	lifetime
+}
+
+//CreateLRNDescriptor - Creates a local response normalization (LRN) layer descriptor
+func CreateLRNDescriptor() (lrnDesc *LRND, err error) {
+	lrnDesc = new(LRND)
+	lrnDesc.start("LRND")
+	runtime.SetFinalizer(lrnDesc, (*LRND).Destroy)
+	return lrnDesc, nil
+}
+
+//Destroy stops tracking the descriptor for leaks.  The cpu build holds no resources outside of go, but it is
+//kept so code written for the rocm build runs unchanged.  It is safe to call more than once.
+func (l *LRND) Destroy() error {
+	runtime.SetFinalizer(l, nil)
+	return l.end(nil)
+}
+
+//Set - Sets a LRN layer descriptor details
+func (l *LRND) Set(mode LRNMode, n uint32, alpha, beta, k float64) error {
+	if n == 0 {
+		return statusBadParm.error("(l *LRND)Set(): n must be positive")
+	}
+	l.mode, l.n, l.alpha, l.beta, l.k = mode, n, alpha, beta, k
+	return nil
+}
+
+//Get Gets a LRN layer descriptor details. Values are descried in (l *LRND) Set()
+func (l *LRND) Get() (mode LRNMode, n uint32, alpha, beta, k float64, err error) {
+	return l.mode, l.n, l.alpha, l.beta, l.k, nil
+}
+
+//GetWorkSpaceSize - Determine the workspace requirements.
+//
+//The cpu backend recomputes the scale during the backward pass so no workspace is needed.
+func (l *LRND) GetWorkSpaceSize(yD *TensorD) (wspaceSIB uint, err error) {
+	return 0, nil
+}
+
+//LRNMode is used for flags for the LRNMode. Flags are set through its methods
+//
+//Local Response Normalization layer mode
+type LRNMode int32
+
+//WithinChannel sets l and returns LRNMode(WithinChannel) flag
+//
+// Channel independent
+func (l *LRNMode) WithinChannel() LRNMode { *l = LRNMode(0); return *l }
+
+//CrossChannel sets l and returns LRNMode(CrossChannel) flag
+//
+// Cross Channel
+func (l *LRNMode) CrossChannel() LRNMode { *l = LRNMode(1); return *l }
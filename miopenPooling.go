@@ -1,12 +1,26 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
-//#include <miopen/miopen.h>
+/*
+#include <miopen/miopen.h>
+#include <miopen/version.h>
+
+//migo_poolingworkspace uses miopenPoolingGetWorkSpaceSizeV2 if v2 is set and the headers have it.
+static miopenStatus_t migo_poolingworkspace(miopenPoolingDescriptor_t p, miopenTensorDescriptor_t y, size_t *ws, int v2) {
+#if MIOPEN_VERSION_MAJOR > 2 || (MIOPEN_VERSION_MAJOR == 2 && (MIOPEN_VERSION_MINOR > 0 || MIOPEN_VERSION_PATCH >= 1))
+	if (v2) {
+		return miopenPoolingGetWorkSpaceSizeV2(p, y, ws);
+	}
+#endif
+	return miopenPoolingGetWorkSpaceSize(y, ws);
+}
+*/
 import "C"
 import (
 	"errors"
 	"runtime"
-
-	"github.com/dereklstinson/cutil"
 )
 
 //PoolingD - Pooling descriptor is an object that allows the user to specify the dimension sizes of the
@@ -14,6 +28,7 @@ import (
 type PoolingD struct {
 	d    C.miopenPoolingDescriptor_t
 	dims C.int
+	lifetime
 }
 
 //CreatePoolingDescriptor - Creates a pooling layer descriptor
@@ -22,12 +37,21 @@ func CreatePoolingDescriptor() (p *PoolingD, err error) {
 	err = Status(C.miopenCreatePoolingDescriptor(&p.d)).error("CreatePoolingDescriptor")
 
 	runtime.SetFinalizer(p, miopenDestroyPoolingDescriptor)
+	p.start("PoolingD")
 	return p, err
 }
 
 func miopenDestroyPoolingDescriptor(p *PoolingD) error {
-	return Status(C.miopenDestroyPoolingDescriptor(p.d)).error("miopenDestroyPoolingDescriptor")
+	return p.end(func() error {
+		return Status(C.miopenDestroyPoolingDescriptor(p.d)).error("miopenDestroyPoolingDescriptor")
+	})
+}
 
+//Destroy frees the descriptor now instead of when it is garbage collected.  It is safe to call more than
+//once, but the descriptor can't be used after.
+func (p *PoolingD) Destroy() error {
+	runtime.SetFinalizer(p, nil)
+	return miopenDestroyPoolingDescriptor(p)
 }
 
 //SetIndexType - Set index data type for pooling layer. The default indexing type is uint8_t.
@@ -56,8 +80,8 @@ func (p *PoolingD) GetIndexType() (index IndexType, err error) {
 //	pad          Number of elements to pad (input)
 //	stride       Number of elements to stride over (input)
 func (p *PoolingD) Set(mode PoolingMode, window, pad, stride []int32) error {
-	if len(window) != 2 || len(pad) != 2 || len(stride) != 2 {
-		return errors.New("(*Pooling)Set() : len(window)!=2 || len(pad) !=2 ||len(stride)!=2")
+	if err := checkpoolingset("(*Pooling)Set()", window, pad, stride); err != nil {
+		return err
 	}
 	p.dims = (C.int)(len(window))
 	padding := int32Tocint(pad)
@@ -70,6 +94,9 @@ func (p *PoolingD) Set(mode PoolingMode, window, pad, stride []int32) error {
 //
 //Gets the window shape, padding, and stride for a previously created pooling descriptor.
 func (p *PoolingD) Get() (mode PoolingMode, window, pad, stride []int32, err error) {
+	if p.dims == 0 {
+		return mode, nil, nil, nil, errors.New("(p *Pooling)Get(): descriptor not set")
+	}
 	cw := make([]C.int, p.dims)
 	cp := make([]C.int, p.dims)
 	cs := make([]C.int, p.dims)
@@ -101,82 +128,24 @@ func (p *PoolingD) GetForwardOutputDim(tD *TensorD) (dims []int32, err error) {
 //pooling, there is no assumption on index data type. As the user can set the index datatype
 //size using miopenSetPoolingIndexType().
 //
+//MIOpen older than 2.0.1 doesn't have the pooling descriptor version, and the size is worked out from yD
+//alone.
+//
 //yD		Descriptor for pooling layer (input)
 func (p *PoolingD) GetWSpaceSize(yD *TensorD) (wspaceSIB uint, err error) {
-	var ws C.size_t
-	err = Status(C.miopenPoolingGetWorkSpaceSizeV2(p.d, yD.d, &ws)).error("(*PoolingD) GetForwardOutputDim")
+	var (
+		ws C.size_t
+		v2 C.int
+		f  Feature
+	)
+	if Supported(f.PoolingWorkSpaceSizeV2()) == nil {
+		v2 = 1
+	}
+	err = Status(C.migo_poolingworkspace(p.d, yD.d, &ws, v2)).error("(*PoolingD) GetWSpaceSize")
 	wspaceSIB = (uint)(ws)
 	return wspaceSIB, err
 }
 
-//Forward - Execute a forward pooling layer
-//
-//Runs forward pooling. miopenGetPoolingForwardOutputDim() should be called before
-//miopenPoolingForward().
-//If the parameter do_backward == 0, then set workSpace = nullptr and workSpaceSize = 0. However,
-//for back-propagation do_backwards must be set to 1 in miopenPoolingForward().
-//
-//h         MIOpen handle (input)
-//alpha          Floating point scaling factor, allocated on the host (input)
-//xD          Tensor descriptor for data input tensor x (input)
-//x              Data tensor x (input)
-//beta           Floating point shift factor, allocated on the host (input)
-//yD          Tensor descriptor for output data tensor y (input)
-//y              Data tensor y (output)
-//do_backward    Boolean to toggle save data in workspace for backwards pass (input)
-//wspace      Pointer user allocated memory (input)
-//wspaceSIB  Size in bytes of the memory needed (input)
-func (p *PoolingD) Forward(h *Handle, alpha float64,
-	xD *TensorD, x cutil.Mem,
-	beta float64,
-	yD *TensorD, y cutil.Mem,
-	dobackwards bool, wspace cutil.Mem, wspaceSIB uint) error {
-	dtype, _, _, err := xD.Get()
-	if err != nil {
-		return errors.New(err.Error() + " in (*Pooling)Forward()")
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenPoolingForward(h.x, p.d, a1.CPtr(),
-		xD.d, x.Ptr(),
-		b1.CPtr(),
-		yD.d, y.Ptr(),
-		(C.bool)(dobackwards), wspace.Ptr(), (C.size_t)(wspaceSIB))).error("(*Pooling)Forward()")
-}
-
-//Backward - Execute a backward pooling layer
-//
-//Runs backward pooling. (p *PoolingD) GetWSpaceSize() must be called before
-//(p *PoolingD) Backward() to determine the amount of workSpace to be allocated.
-//
-//h         MIOpen handle (input)
-//alpha          Floating point scaling factor, allocated on the host (input)
-//yD          Tensor descriptor for output data tensor y (input)
-//y              Data tensor y (input)
-//dyD         Tensor descriptor for data input tensor dy (input)
-//dy             Data delta tensor dy (input)
-//xD          Tensor descriptor for output data tensor x (input)
-//x              Data tensor x (output)
-//beta           Floating point shift factor, allocated on the host (input)
-//dxD         Tensor descriptor for tensor dx (input)
-//dx             Weights delta tensor dx (output)
-//wspace      Pointer to user allocated workspace (input)
-func (p *PoolingD) Backward(h *Handle, alpha float64,
-	yD *TensorD, y cutil.Mem,
-	dyD *TensorD, dy cutil.Mem,
-	xD *TensorD, x cutil.Mem,
-	beta float64,
-	dxD *TensorD, dx cutil.Mem,
-	wspace cutil.Mem) error {
-	dtype, _, _, err := xD.Get()
-	if err != nil {
-		return errors.New(err.Error() + " in (*Pooling)Backward()")
-	}
-	a1 := cscalarbydatatype(dtype, alpha)
-	b1 := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenPoolingBackward(h.x, p.d, a1.CPtr(), yD.d, y.Ptr(), dyD.d, dy.Ptr(), xD.d, x.Ptr(), b1.CPtr(), dxD.d, dx.Ptr(), wspace.Ptr())).error("(*PoolingD)Backward()")
-}
-
 //PoolingMode is used for flags in pooling
 type PoolingMode C.miopenPoolingMode_t
 
@@ -0,0 +1,97 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import "runtime"
+
+//PoolingD - Pooling descriptor is an object that allows the user to specify the dimension sizes of the
+//pooling windows, paddings, strides, and pooling mode.
+type PoolingD struct {
+	mode                PoolingMode
+	window, pad, stride []int32
+	index               IndexType
+	lifetime
+}
+
+//CreatePoolingDescriptor - Creates a pooling layer descriptor
+func CreatePoolingDescriptor() (p *PoolingD, err error) {
+	p = new(PoolingD)
+	p.start("PoolingD")
+	runtime.SetFinalizer(p, (*PoolingD).Destroy)
+	return p, nil
+}
+
+//Destroy stops tracking the descriptor for leaks.  The cpu build holds no resources outside of go, but it is
+//kept so code written for the rocm build runs unchanged.  It is safe to call more than once.
+func (p *PoolingD) Destroy() error {
+	runtime.SetFinalizer(p, nil)
+	return p.end(nil)
+}
+
+//SetIndexType - Set index data type for pooling layer. The default indexing type is uint8_t.
+func (p *PoolingD) SetIndexType(index IndexType) error {
+	p.index = index
+	return nil
+}
+
+//GetIndexType - Get the index data type for pooling layer.
+func (p *PoolingD) GetIndexType() (index IndexType, err error) {
+	return p.index, nil
+}
+
+//Set - Sets a pooling layer descriptor details. (2D only right now)
+func (p *PoolingD) Set(mode PoolingMode, window, pad, stride []int32) error {
+	if err := checkpoolingset("(*Pooling)Set()", window, pad, stride); err != nil {
+		return err
+	}
+	p.mode = mode
+	p.window = append([]int32(nil), window...)
+	p.pad = append([]int32(nil), pad...)
+	p.stride = append([]int32(nil), stride...)
+	return nil
+}
+
+//Get - Gets layer descriptor details. (2D only right now)
+func (p *PoolingD) Get() (mode PoolingMode, window, pad, stride []int32, err error) {
+	if p.window == nil {
+		return mode, nil, nil, nil, statusBadParm.error("(p *Pooling)Get(): descriptor not set")
+	}
+	return p.mode, append([]int32(nil), p.window...), append([]int32(nil), p.pad...), append([]int32(nil), p.stride...), nil
+}
+
+//GetForwardOutputDim - Gets the shape of the output tensor
+func (p *PoolingD) GetForwardOutputDim(tD *TensorD) (dims []int32, err error) {
+	if p.window == nil {
+		return nil, statusBadParm.error("(p *Pooling)GetForwardOutputDim(): descriptor not set")
+	}
+	if !tD.set || len(tD.shape) != 4 {
+		return nil, statusBadParm.error("(p *Pooling)GetForwardOutputDim(): input must be a 4d tensor")
+	}
+	return PoolingOutputDim(tD.shape, p.window, p.pad, p.stride)
+}
+
+//GetWSpaceSize - Get the amount of memory required for pooling
+//
+//The cpu backend recomputes indices from x during the backward pass so no workspace is needed.
+func (p *PoolingD) GetWSpaceSize(yD *TensorD) (wspaceSIB uint, err error) {
+	return 0, nil
+}
+
+//PoolingMode is used for flags in pooling
+type PoolingMode int32
+
+//Max sets p and returns PoolingMode(Max) flag
+//
+//The maximum value inside the pooling window is used.
+func (p *PoolingMode) Max() PoolingMode { *p = PoolingMode(0); return *p }
+
+//Average sets p and returns PoolingMode(Average) flag
+//
+//Average of the elements inside the window excluding padding
+func (p *PoolingMode) Average() PoolingMode { *p = PoolingMode(1); return *p }
+
+//AverageInclusive returns PoolingMode(AverageInclusive) flag
+//
+//Average of the elements inside the window including padding
+func (p *PoolingMode) AverageInclusive() PoolingMode { *p = PoolingMode(2); return *p }
@@ -1,3 +1,6 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 /*
@@ -104,10 +107,20 @@ func CreateRNNDescriptor() (rnnD *RNND, err error) {
 	rnnD = new(RNND)
 	err = Status(C.miopenCreateRNNDescriptor(&rnnD.d)).error("CreateRNNDescriptor")
 	runtime.SetFinalizer(rnnD, miopenDestroyRNNDescriptor)
+	rnnD.start("RNND")
 	return rnnD, err
 }
 func miopenDestroyRNNDescriptor(r *RNND) error {
-	return Status(C.miopenDestroyRNNDescriptor(r.d)).error("miopenDestroyRNNDescriptor")
+	return r.end(func() error {
+		return Status(C.miopenDestroyRNNDescriptor(r.d)).error("miopenDestroyRNNDescriptor")
+	})
+}
+
+//Destroy frees the descriptor now instead of when it is garbage collected.  It is safe to call more than
+//once, but the descriptor can't be used after.
+func (r *RNND) Destroy() error {
+	runtime.SetFinalizer(r, nil)
+	return miopenDestroyRNNDescriptor(r)
 }
 
 //Set - Set the details of the RNN descriptor
@@ -763,7 +776,7 @@ func (r *RNND) ForwardTraining(h *Handle,
 		&yDc[0], y.Ptr(),
 		hyD.d, hy.Ptr(),
 		cyD.d, cy.Ptr(),
-		wspace.Ptr(), (C.size_t)(wspaceSIB),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB),
 		rspace.Ptr(), (C.size_t)(rspaceSIB))).error("(r *RNND)ForwardTraining()")
 }
 
@@ -895,7 +908,7 @@ func (r *RNND) BackwardData(h *Handle,
 		&dxDc[0], dx.Ptr(),
 		dhxD.d, dhx.Ptr(),
 		dcxD.d, dcx.Ptr(),
-		wspace.Ptr(), (C.size_t)(wspaceSIB),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB),
 		rspace.Ptr(), (C.size_t)(rspaceSIB))).error("(r *RNND)BackwardData()")
 }
 
@@ -966,7 +979,7 @@ func (r *RNND) BackwardWeights(h *Handle,
 		hxD.d, hx.Ptr(),
 		&yDc[0], y.Ptr(),
 		dwD.d, dw.Ptr(),
-		wspace.Ptr(), (C.size_t)(wspaceSIB),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB),
 		rspace.Ptr(), (C.size_t)(rspaceSIB))).error("(r *RNND)BackwardWeights()")
 }
 
@@ -1061,5 +1074,35 @@ func (r *RNND) ForwardInference(h *Handle,
 		&yDc[0], y.Ptr(),
 		hyD.d, hy.Ptr(),
 		cyD.d, cy.Ptr(),
-		wspace.Ptr(), (C.size_t)(wspaceSIB))).error("(r *RNND)ForwardInference()")
+		wspaceptr(wspace), (C.size_t)(wspaceSIB))).error("(r *RNND)ForwardInference()")
+}
+
+//workspace gets a workspace from h.Workspace() sized by GetWorkspaceSize
+func (r *RNND) workspace(h *Handle, xD []*TensorD) (wspace cutil.Mem, wspaceSIB uint, err error) {
+	wspaceSIB, err = r.GetWorkspaceSize(h, xD)
+	if err != nil {
+		return nil, 0, err
+	}
+	wspace, err = h.Workspace().Get(wspaceSIB)
+	return wspace, wspaceSIB, err
+}
+
+//There are no Managed versions of ForwardTraining, BackwardData and BackwardWeights.  MIOpen keeps data in
+//the workspace between the training calls, so another operation on the handle could overwrite it.  Size it
+//with GetWorkspaceSize() and keep it with the RNND, like the reserve space.
+
+//ForwardInferenceManaged is ForwardInference with a workspace from h.Workspace()
+func (r *RNND) ForwardInferenceManaged(h *Handle,
+	xD []*TensorD, x cutil.Mem,
+	hxD *TensorD, hx cutil.Mem,
+	cxD *TensorD, cx cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	yD []*TensorD, y cutil.Mem,
+	hyD *TensorD, hy cutil.Mem,
+	cyD *TensorD, cy cutil.Mem) error {
+	wspace, wspaceSIB, err := r.workspace(h, xD)
+	if err != nil {
+		return err
+	}
+	return r.ForwardInference(h, xD, x, hxD, hx, cxD, cx, wD, w, yD, y, hyD, hy, cyD, cy, wspace, wspaceSIB)
 }
@@ -0,0 +1,179 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
+package miopen
+
+/*
+#include <miopen/miopen.h>
+#include <miopen/version.h>
+
+//the reduction API came with MIOpen 2.11, the shims return miopenStatusUnsupportedOp for older headers.
+#if MIOPEN_VERSION_MAJOR > 2 || (MIOPEN_VERSION_MAJOR == 2 && MIOPEN_VERSION_MINOR >= 11)
+#define MIGO_REDUCETENSOR 1
+typedef miopenReduceTensorDescriptor_t migo_reducedesc;
+#else
+typedef void* migo_reducedesc;
+#endif
+
+static miopenStatus_t migo_createreduce(migo_reducedesc* r) {
+#ifdef MIGO_REDUCETENSOR
+	return miopenCreateReduceTensorDescriptor(r);
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
+
+static miopenStatus_t migo_destroyreduce(migo_reducedesc r) {
+#ifdef MIGO_REDUCETENSOR
+	return miopenDestroyReduceTensorDescriptor(r);
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
+
+static miopenStatus_t migo_setreduce(migo_reducedesc r, int op, miopenDataType_t comp, int nan, int indices, int itype) {
+#ifdef MIGO_REDUCETENSOR
+	return miopenSetReduceTensorDescriptor(r, (miopenReduceTensorOp_t)op, comp, (miopenNanPropagation_t)nan,
+		(miopenReduceTensorIndices_t)indices, (miopenIndicesType_t)itype);
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
+
+static miopenStatus_t migo_getreduce(migo_reducedesc r, int* op, miopenDataType_t* comp, int* nan, int* indices, int* itype) {
+#ifdef MIGO_REDUCETENSOR
+	miopenReduceTensorOp_t o;
+	miopenNanPropagation_t n;
+	miopenReduceTensorIndices_t i;
+	miopenIndicesType_t t;
+	miopenStatus_t s = miopenGetReduceTensorDescriptor(r, &o, comp, &n, &i, &t);
+	*op = o;
+	*nan = n;
+	*indices = i;
+	*itype = t;
+	return s;
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
+
+static miopenStatus_t migo_reduceindicessize(miopenHandle_t h, migo_reducedesc r,
+	miopenTensorDescriptor_t aDesc, miopenTensorDescriptor_t cDesc, size_t* sib) {
+#ifdef MIGO_REDUCETENSOR
+	return miopenGetReductionIndicesSize(h, r, aDesc, cDesc, sib);
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
+
+static miopenStatus_t migo_reduceworkspacesize(miopenHandle_t h, migo_reducedesc r,
+	miopenTensorDescriptor_t aDesc, miopenTensorDescriptor_t cDesc, size_t* sib) {
+#ifdef MIGO_REDUCETENSOR
+	return miopenGetReductionWorkspaceSize(h, r, aDesc, cDesc, sib);
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
+
+static miopenStatus_t migo_reducetensor(miopenHandle_t h, migo_reducedesc r,
+	void* indices, size_t indicesSIB, void* wspace, size_t wspaceSIB,
+	const void* alpha, miopenTensorDescriptor_t aDesc, const void* A,
+	const void* beta, miopenTensorDescriptor_t cDesc, void* C) {
+#ifdef MIGO_REDUCETENSOR
+	return miopenReduceTensor(h, r, indices, indicesSIB, wspace, wspaceSIB, alpha, aDesc, A, beta, cDesc, C);
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
+*/
+import "C"
+import (
+	"runtime"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//ReduceTensorD - Reduce tensor descriptor is an object that allows the user to specify the reduction
+//operation, the data type it is computed in, and if NaNs and indices are returned.
+type ReduceTensorD struct {
+	d C.migo_reducedesc
+	lifetime
+}
+
+//CreateReduceTensorDescriptor - Creates the reduce tensor descriptor object.  It returns an *UnsupportedError
+//if MIOpen doesn't have Feature.ReduceTensor.
+func CreateReduceTensorDescriptor() (r *ReduceTensorD, err error) {
+	if err = Supported(new(Feature).ReduceTensor()); err != nil {
+		return nil, err
+	}
+	r = new(ReduceTensorD)
+	err = Status(C.migo_createreduce(&r.d)).error("CreateReduceTensorDescriptor")
+	if err != nil {
+		return nil, err
+	}
+	runtime.SetFinalizer(r, miopenDestroyReduceTensorDescriptor)
+	r.start("ReduceTensorD")
+	return r, nil
+}
+
+func miopenDestroyReduceTensorDescriptor(r *ReduceTensorD) error {
+	return r.end(func() error {
+		return Status(C.migo_destroyreduce(r.d)).error("miopenDestroyReduceTensorDescriptor")
+	})
+}
+
+//Destroy frees the descriptor now instead of when it is garbage collected.  It is safe to call more than
+//once, but the descriptor can't be used after.
+func (r *ReduceTensorD) Destroy() error {
+	runtime.SetFinalizer(r, nil)
+	return miopenDestroyReduceTensorDescriptor(r)
+}
+
+//Set - Sets the reduce tensor descriptor details
+//
+//	op		Reduction operation (input)
+//	comp		Data type the reduction is computed in (input)
+//	nan		If NaNs are propagated (input)
+//	indices		If Min, Max and AMax return the indices of the elements they picked (input)
+//	itype		Data type of the indices (input)
+func (r *ReduceTensorD) Set(op ReduceTensorOp, comp DataType, nan NanPropagation, indices ReduceTensorIndices, itype IndicesType) error {
+	return Status(C.migo_setreduce(r.d, C.int(op), comp.c(), C.int(nan), C.int(indices), C.int(itype))).error("(r *ReduceTensorD)Set()")
+}
+
+//Get - Gets the reduce tensor descriptor details
+func (r *ReduceTensorD) Get() (op ReduceTensorOp, comp DataType, nan NanPropagation, indices ReduceTensorIndices, itype IndicesType, err error) {
+	var o, n, i, t C.int
+	err = Status(C.migo_getreduce(r.d, &o, comp.cptr(), &n, &i, &t)).error("(r *ReduceTensorD)Get()")
+	return ReduceTensorOp(o), comp, NanPropagation(n), ReduceTensorIndices(i), IndicesType(t), err
+}
+
+//The rocmBackend reduction methods are here, next to the shims they call.
+
+func (r *rocmBackend) GetReductionIndicesSize(rt *ReduceTensorD, aD, cD *TensorD) (indicesSIB uint, err error) {
+	var sib C.size_t
+	err = Status(C.migo_reduceindicessize(r.x, rt.d, aD.d, cD.d, &sib)).error("(r *ReduceTensorD)GetIndicesSize()")
+	return uint(sib), err
+}
+
+func (r *rocmBackend) GetReductionWorkspaceSize(rt *ReduceTensorD, aD, cD *TensorD) (wspaceSIB uint, err error) {
+	var sib C.size_t
+	err = Status(C.migo_reduceworkspacesize(r.x, rt.d, aD.d, cD.d, &sib)).error("(r *ReduceTensorD)GetWorkSpaceSize()")
+	return uint(sib), err
+}
+
+func (r *rocmBackend) ReduceTensor(rt *ReduceTensorD,
+	indices cutil.Mem, indicesSIB uint,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha float64, aD *TensorD, a cutil.Mem,
+	beta float64, cD *TensorD, c cutil.Mem) error {
+	dtype, _, _, err := aD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.migo_reducetensor(r.x, rt.d, wspaceptr(indices), (C.size_t)(indicesSIB),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB),
+		a1.CPtr(), aD.d, a.Ptr(),
+		b1.CPtr(), cD.d, c.Ptr())).error("(r *ReduceTensorD)Reduce()")
+}
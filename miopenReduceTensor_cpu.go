@@ -0,0 +1,43 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import "runtime"
+
+//ReduceTensorD - Reduce tensor descriptor is an object that allows the user to specify the reduction
+//operation, the data type it is computed in, and if NaNs and indices are returned.
+type ReduceTensorD struct {
+	op      ReduceTensorOp
+	comp    DataType
+	nan     NanPropagation
+	indices ReduceTensorIndices
+	itype   IndicesType
+	lifetime
+}
+
+//CreateReduceTensorDescriptor - Creates the reduce tensor descriptor object
+func CreateReduceTensorDescriptor() (r *ReduceTensorD, err error) {
+	r = new(ReduceTensorD)
+	r.start("ReduceTensorD")
+	runtime.SetFinalizer(r, (*ReduceTensorD).Destroy)
+	return r, nil
+}
+
+//Destroy stops tracking the descriptor for leaks.  The cpu build holds no resources outside of go, but it is
+//kept so code written for the rocm build runs unchanged.  It is safe to call more than once.
+func (r *ReduceTensorD) Destroy() error {
+	runtime.SetFinalizer(r, nil)
+	return r.end(nil)
+}
+
+//Set - Sets the reduce tensor descriptor details.  The cpu build computes in float64 whatever comp is.
+func (r *ReduceTensorD) Set(op ReduceTensorOp, comp DataType, nan NanPropagation, indices ReduceTensorIndices, itype IndicesType) error {
+	r.op, r.comp, r.nan, r.indices, r.itype = op, comp, nan, indices, itype
+	return nil
+}
+
+//Get - Gets the reduce tensor descriptor details
+func (r *ReduceTensorD) Get() (op ReduceTensorOp, comp DataType, nan NanPropagation, indices ReduceTensorIndices, itype IndicesType, err error) {
+	return r.op, r.comp, r.nan, r.indices, r.itype, nil
+}
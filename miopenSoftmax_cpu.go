@@ -0,0 +1,15 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+//SoftMaxD holds the methods to call the soft max function. This is so it keeps uniform with the other descriptors
+//
+//Like MIOpen the cpu build implements the SOFTMAX_MODE_CHANNEL flavor.
+type SoftMaxD struct {
+}
+
+//CreateSoftMax - Creates a soft max method holder
+func CreateSoftMax() (*SoftMaxD, error) {
+	return &SoftMaxD{}, nil
+}
@@ -1,3 +1,6 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 /*
@@ -5,34 +8,20 @@ package miopen
 
 */
 import "C"
-import "errors"
 
 //Status is the error return used in miopen
 //Since miopen is supposed to be like cudnn. I made this public so that I could copy and paste gocudnn functions easier.
 //
 type Status C.miopenStatus_t
 
-func (s Status) error(comment string) error {
-	x := (C.miopenStatus_t)(s)
-	switch x {
-	case C.miopenStatusSuccess:
-		return nil
-	case C.miopenStatusNotInitialized:
-		return errors.New("miopenStatusNotInitialized : " + comment)
-	case C.miopenStatusInvalidValue:
-		return errors.New("miopenStatusInvalidValue : " + comment)
-	case C.miopenStatusBadParm:
-		return errors.New("miopenStatusBadParm : " + comment)
-	case C.miopenStatusAllocFailed:
-		return errors.New("miopenStatusAllocFailed : " + comment)
-	case C.miopenStatusInternalError:
-		return errors.New("miopenStatusInternalError : " + comment)
-	case C.miopenStatusNotImplemented:
-		return errors.New("miopenStatusNotImplemented : " + comment)
-	case C.miopenStatusUnknownError:
-		return errors.New("miopenStatusUnknownError : " + comment)
-	case C.miopenStatusUnsupportedOp:
-		return errors.New("miopenStatusUnsupportedOp : " + comment)
-	}
-	return errors.New("go miopen new error not in go binding")
-}
+const (
+	statusSuccess        = Status(C.miopenStatusSuccess)
+	statusNotInitialized = Status(C.miopenStatusNotInitialized)
+	statusInvalidValue   = Status(C.miopenStatusInvalidValue)
+	statusBadParm        = Status(C.miopenStatusBadParm)
+	statusAllocFailed    = Status(C.miopenStatusAllocFailed)
+	statusInternalError  = Status(C.miopenStatusInternalError)
+	statusNotImplemented = Status(C.miopenStatusNotImplemented)
+	statusUnknownError   = Status(C.miopenStatusUnknownError)
+	statusUnsupportedOp  = Status(C.miopenStatusUnsupportedOp)
+)
@@ -0,0 +1,21 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+//Status is the error return used in miopen
+//
+//In the cpu build the values mirror miopenStatus_t so that errors read the same as they do on a device.
+type Status int32
+
+const (
+	statusSuccess Status = iota
+	statusNotInitialized
+	statusInvalidValue
+	statusBadParm
+	statusAllocFailed
+	statusInternalError
+	statusNotImplemented
+	statusUnknownError
+	statusUnsupportedOp
+)
@@ -0,0 +1,26 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
+package miopen
+
+/*
+#include <miopen/miopen.h>
+#include <miopen/version.h>
+
+//The immediate mode calls, miopenConvAlgorithm_t and miopenGetVersion came with MIOpen 2.0 and are used
+//without shims, so 2.0 is the oldest version of the headers the package builds against.
+#if MIOPEN_VERSION_MAJOR < 2
+#error "migo needs the MIOpen 2.0 headers or newer"
+#endif
+*/
+import "C"
+
+func headerversion() SemVer {
+	return SemVer{C.MIOPEN_VERSION_MAJOR, C.MIOPEN_VERSION_MINOR, C.MIOPEN_VERSION_PATCH}
+}
+
+func libraryversion() (SemVer, error) {
+	var major, minor, patch C.size_t
+	err := Status(C.miopenGetVersion(&major, &minor, &patch)).error("Version()")
+	return SemVer{int(major), int(minor), int(patch)}, err
+}
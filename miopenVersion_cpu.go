@@ -0,0 +1,9 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+//headerversion is the newest MIOpen version the cpu build mirrors, so every Feature is supported.
+func headerversion() SemVer { return SemVer{2, 16, 0} }
+
+func libraryversion() (SemVer, error) { return headerversion(), nil }
@@ -0,0 +1,59 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
+package miopen
+
+//#include <hip/hip_runtime_api.h>
+import "C"
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//devicemem is device memory allocated with hipMalloc
+type devicemem struct {
+	p   unsafe.Pointer
+	sib uint
+}
+
+func (d *devicemem) Ptr() unsafe.Pointer   { return d.p }
+func (d *devicemem) DPtr() *unsafe.Pointer { return &d.p }
+func (d *devicemem) SIB() uint             { return d.sib }
+
+//hipallocator allocates with hipMalloc and frees with hipFree
+type hipallocator struct{}
+
+func defaultallocator() Allocator {
+	return hipallocator{}
+}
+
+func (hipallocator) Allocate(sib uint) (cutil.Mem, error) {
+	if err := hipinit(); err != nil {
+		return nil, err
+	}
+	d := &devicemem{sib: sib}
+	if x := C.hipMalloc(&d.p, C.size_t(sib)); x != 0 {
+		return nil, &Error{Status: statusAllocFailed, Op: fmt.Sprintf("hipMalloc(%d): hipError_t(%d)", sib, int(x))}
+	}
+	return d, nil
+}
+
+func (hipallocator) Free(m cutil.Mem) error {
+	if x := C.hipFree(m.Ptr()); x != 0 {
+		return &Error{Status: statusInternalError, Op: fmt.Sprintf("hipFree: hipError_t(%d)", int(x))}
+	}
+	return nil
+}
+
+//memcpy copies sib bytes from src to dst with hipMemcpyDefault, so either can be host or device memory.
+func memcpy(dst, src unsafe.Pointer, sib uint) error {
+	if sib == 0 {
+		return nil
+	}
+	if x := C.hipMemcpy(dst, src, C.size_t(sib), C.hipMemcpyDefault); x != 0 {
+		return &Error{Status: statusInternalError, Op: fmt.Sprintf("hipMemcpy(%d): hipError_t(%d)", sib, int(x))}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+//defaultallocator allocates go memory, which the cpu backend uses as device memory.
+func defaultallocator() Allocator {
+	return hostallocator{}
+}
+
+//memcpy copies sib bytes from src to dst.  All memory is host memory in the cpu build.
+func memcpy(dst, src unsafe.Pointer, sib uint) error {
+	if sib == 0 {
+		return nil
+	}
+	copy(bytesof(dst, sib), bytesof(src, sib))
+	return nil
+}
+
+func bytesof(p unsafe.Pointer, sib uint) (b []byte) {
+	h := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+	h.Data, h.Len, h.Cap = uintptr(p), int(sib), int(sib)
+	return b
+}
@@ -0,0 +1,57 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+//DataType is used for flags for the tensor layer structs
+type DataType int32
+
+// Float sets d to Float and returns the changed value
+func (d *DataType) Float() DataType { *d = DataType(1); return *d }
+
+// Int8 sets d to Int8 and returns the changed value
+func (d *DataType) Int8() DataType { *d = DataType(3); return *d }
+
+// Int32 sets d to Int32 and returns the changed value
+func (d *DataType) Int32() DataType { *d = DataType(2); return *d }
+
+//Half sets d to Half and returns the changed value
+func (d *DataType) Half() DataType { *d = DataType(0); return *d }
+
+//Int8x4 sets d to  Int8x4 and returns the changed value
+//
+//Not Supported by the cpu backend
+func (d *DataType) Int8x4() DataType { *d = DataType(4); return *d }
+
+//ToString will return a human readable string that can be printed for debugging.
+func (d DataType) ToString() string {
+	var flg DataType
+	switch d {
+	case flg.Float():
+		return "Float"
+	case flg.Int8():
+		return "Int8"
+	case flg.Int32():
+		return "Int32"
+	case flg.Half():
+		return "Half"
+	case flg.Int8x4():
+		return "Int8x4"
+	}
+	return "ERROR no such flag"
+}
+
+//IndexType MIOpen index datatypes.
+type IndexType int32
+
+//Uint8 sets i to Uint8 and returns the changed value
+func (i *IndexType) Uint8() IndexType { *i = IndexType(0); return *i }
+
+//Uint16 sets i to Uint16 and returns the changed value
+func (i *IndexType) Uint16() IndexType { *i = IndexType(1); return *i }
+
+//Uint32 sets i to Uint32 and returns the changed value
+func (i *IndexType) Uint32() IndexType { *i = IndexType(2); return *i }
+
+//Uint64 sets i to Uint64 and returns the changed value
+func (i *IndexType) Uint64() IndexType { *i = IndexType(3); return *i }
@@ -0,0 +1,19 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+//OpTensorOp is used for flags for the Optensor functions
+type OpTensorOp int32
+
+//Add sets o to OpTensorOp(Add) and returns the new value
+func (o *OpTensorOp) Add() OpTensorOp { *o = OpTensorOp(0); return *o }
+
+//Mul sets o to OpTensorOp(Mul) and returns the new value
+func (o *OpTensorOp) Mul() OpTensorOp { *o = OpTensorOp(1); return *o }
+
+//Min sets o to OpTensorOp(Min)  and returns the new value
+func (o *OpTensorOp) Min() OpTensorOp { *o = OpTensorOp(2); return *o }
+
+//Max sets o to OpTensorOp(Max) and returns the new value
+func (o *OpTensorOp) Max() OpTensorOp { *o = OpTensorOp(3); return *o }
@@ -0,0 +1,14 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import "unsafe"
+
+//Streamer allowes streams from other packages to be used with this package
+//
+//The cpu build runs everything synchronously so streams are only carried by the handle.
+type Streamer interface {
+	Ptr() unsafe.Pointer
+	Sync() error
+}
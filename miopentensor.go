@@ -1,3 +1,6 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
 package miopen
 
 /*
@@ -7,8 +10,6 @@ package miopen
 import "C"
 import (
 	"runtime"
-
-	"github.com/dereklstinson/cutil"
 )
 
 const miopendimmax = 5
@@ -18,6 +19,7 @@ type TensorD struct {
 	d    C.miopenTensorDescriptor_t
 	gogc bool
 	dims C.int
+	lifetime
 }
 
 //CreateTensorDescriptor creates an empty tensor descriptor
@@ -26,8 +28,16 @@ func CreateTensorDescriptor() (*TensorD, error) {
 
 }
 func destroytensordescriptor(t *TensorD) error {
-	return Status(C.miopenDestroyTensorDescriptor(t.d)).error("destroytensordescriptor")
+	return t.end(func() error {
+		return Status(C.miopenDestroyTensorDescriptor(t.d)).error("destroytensordescriptor")
+	})
+}
 
+//Destroy frees the descriptor now instead of when it is garbage collected.  It is safe to call more than
+//once, but the descriptor can't be used after.
+func (t *TensorD) Destroy() error {
+	runtime.SetFinalizer(t, nil)
+	return destroytensordescriptor(t)
 }
 func createtensordescriptor() (*TensorD, error) {
 	d := new(TensorD)
@@ -38,12 +48,16 @@ func createtensordescriptor() (*TensorD, error) {
 	}
 
 	runtime.SetFinalizer(d, destroytensordescriptor)
+	d.start("TensorD")
 
 	return d, nil
 }
 
 //Set sets the t's values
 func (t *TensorD) Set(data DataType, shape, stride []int32) error {
+	if err := checktensorset("(t *TensorD)Set()", shape, stride); err != nil {
+		return err
+	}
 	t.dims = (C.int)(len(shape))
 	//	t.dtype = data
 	if stride == nil {
@@ -89,36 +103,6 @@ func (t *TensorD) GetNumOfElements() (num int32, err error) {
 	return num, err
 }
 
-//SetAll - Fills a tensor with a single value.
-//
-func (t *TensorD) SetAll(h *Handle, tmem cutil.Mem, alpha float64) error {
-	dtype, _, _, err := t.Get()
-	if err != nil {
-		return err
-	}
-	val := cscalarbydatatype(dtype, alpha)
-	return Status(C.miopenSetTensor(h.x, t.d, tmem.Ptr(), val.CPtr())).error("SetAll")
-
-}
-
-//Scale - Scales all elements in a tensor by a single value.
-//
-//	h		MiOpen handle (input)
-//
-//	tmem		Tensor Memory (input and output)
-//
-//	alpha		Floating point scaling factor, allocated on the host (input)
-//
-func (t *TensorD) Scale(h *Handle, tmem cutil.Mem, alpha float64) error {
-	dtype, _, _, err := t.Get()
-	if err != nil {
-		return err
-	}
-	val := cscalarbydatatype(dtype, alpha)
-	return Status(C.miopenScaleTensor(h.x, t.d, tmem.Ptr(), val.CPtr())).error("Scale")
-
-}
-
 //GetSIB -  Returns number of bytes associated with tensor descriptor
 //
 func (t *TensorD) GetSIB() (sib uint, err error) {
@@ -127,29 +111,3 @@ func (t *TensorD) GetSIB() (sib uint, err error) {
 	sib = (uint)(sizet)
 	return sib, err
 }
-
-//TransformTensor - Copies one tensor to another tensor with a different layout.
-//
-//	h         MIOpen handle (input)
-//
-//	alpha     Floating point scaling factor, allocated on the host (input)
-//
-//	xD	      Source Tensor descriptor for tensor x (input)
-//
-//	x         Source Tensor x (input)
-//
-//	beta       Floating point scaling factor, allocated on the host (input)
-//
-//	yD	      Destination Tensor descriptor for tensor y (input)
-//
-//	y         Destination Tensor y (output)
-//
-func TransformTensor(h *Handle, alpha float64, xD *TensorD, x cutil.Mem, beta float64, yD *TensorD, y cutil.Mem) error {
-	dtype, _, _, err := xD.Get()
-	if err != nil {
-		return err
-	}
-	a := cscalarbydatatype(dtype, alpha)
-	b := cscalarbydatatype(dtype, beta)
-	return Status(C.miopenTransformTensor(h.x, a.CPtr(), xD.d, x.Ptr(), b.CPtr(), yD.d, y.Ptr())).error("TransformTensor")
-}
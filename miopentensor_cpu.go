@@ -0,0 +1,93 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen
+
+import "runtime"
+
+const miopendimmax = 5
+
+//TensorD is a tensor descriptor
+type TensorD struct {
+	dtype  DataType
+	shape  []int32
+	stride []int32
+	set    bool
+	lifetime
+}
+
+//CreateTensorDescriptor creates an empty tensor descriptor
+func CreateTensorDescriptor() (*TensorD, error) {
+	return createtensordescriptor()
+}
+
+func createtensordescriptor() (*TensorD, error) {
+	t := new(TensorD)
+	t.start("TensorD")
+	runtime.SetFinalizer(t, (*TensorD).Destroy)
+	return t, nil
+}
+
+//Destroy stops tracking the descriptor for leaks.  The cpu build holds no resources outside of go, but it is
+//kept so code written for the rocm build runs unchanged.  It is safe to call more than once.
+func (t *TensorD) Destroy() error {
+	runtime.SetFinalizer(t, nil)
+	return t.end(nil)
+}
+
+//Set sets the t's values
+func (t *TensorD) Set(data DataType, shape, stride []int32) error {
+	if err := checktensorset("(t *TensorD)Set()", shape, stride); err != nil {
+		return err
+	}
+	if stride == nil {
+		stride = stridecalc(shape)
+	}
+	t.dtype = data
+	t.shape = append([]int32(nil), shape...)
+	t.stride = append([]int32(nil), stride...)
+	t.set = true
+	return nil
+}
+
+//Get gets t's values
+func (t *TensorD) Get() (dtype DataType, shape []int32, stride []int32, err error) {
+	if !t.set {
+		return dtype, nil, nil, statusBadParm.error("(t *TensorD)Get(): descriptor not set")
+	}
+	return t.dtype, append([]int32(nil), t.shape...), append([]int32(nil), t.stride...), nil
+}
+
+//GetNumOfElements - Get Tensor Volume by elements
+func (t *TensorD) GetNumOfElements() (num int32, err error) {
+	if !t.set {
+		return 0, statusBadParm.error("GetNumOfElements")
+	}
+	return findvolume(t.shape), nil
+}
+
+//GetSIB -  Returns number of bytes associated with tensor descriptor
+//
+//Like MIOpen this is the size of the element space spanned by the strides, not the packed size.
+func (t *TensorD) GetSIB() (sib uint, err error) {
+	if !t.set {
+		return 0, statusBadParm.error("GetSIB")
+	}
+	space := uint(1)
+	for i := range t.shape {
+		space += uint(t.shape[i]-1) * uint(t.stride[i])
+	}
+	return space * datatypesize(t.dtype), nil
+}
+
+//datatypesize returns the size in bytes of a single element of dtype
+func datatypesize(dtype DataType) uint {
+	var flg DataType
+	switch dtype {
+	case flg.Half():
+		return 2
+	case flg.Int8():
+		return 1
+	}
+	return 4
+}
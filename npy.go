@@ -0,0 +1,281 @@
+package miopen
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+//serialtype is a DataType that can be written to files, with its size and its codes in .npy and
+//safetensors files.  Values are written little endian.
+type serialtype struct {
+	dtype       DataType
+	size        int
+	npy         string
+	safetensors string
+}
+
+func serialtypes() []serialtype {
+	var f DataType
+	return []serialtype{
+		{f.Float(), 4, "<f4", "F32"},
+		{f.Half(), 2, "<f2", "F16"},
+		{f.Int8(), 1, "|i1", "I8"},
+		{f.Int32(), 4, "<i4", "I32"},
+	}
+}
+
+func serialtypeof(op string, dtype DataType) (serialtype, error) {
+	for _, st := range serialtypes() {
+		if st.dtype == dtype {
+			return st, nil
+		}
+	}
+	return serialtype{}, statusNotImplemented.error(fmt.Sprintf("%s: DataType %s can't be written, only Float, Half, Int8 and Int32 can", op, dtype.ToString()))
+}
+
+//packed copies t to go in packed row-major order, following the strides of its descriptor.
+func (t *Tensor) packed(op string) (data []byte, st serialtype, shape []int32, err error) {
+	dtype, shape, stride, err := t.d.Get()
+	if err != nil {
+		return nil, st, nil, err
+	}
+	if st, err = serialtypeof(op, dtype); err != nil {
+		return nil, st, nil, err
+	}
+	host, err := t.host()
+	if err != nil {
+		return nil, st, nil, err
+	}
+	n := int(findvolume(shape)) * st.size
+	if comparedims(stride, stridecalc(shape)) {
+		return host.b[:n], st, shape, nil
+	}
+	v := &hostview{p: host.p, dtype: dtype, shape: shape, stride: stride}
+	data = make([]byte, 0, n)
+	for _, off := range v.offsets() {
+		data = append(data, host.b[off*st.size:(off+1)*st.size]...)
+	}
+	return data, st, shape, nil
+}
+
+//fromhost allocates a tensor and copies data, laid out as stride describes, into it.
+func fromhost(a Allocator, dtype DataType, shape, stride []int32, data []byte) (*Tensor, error) {
+	t, err := newtensor(a, dtype, shape, stride)
+	if err != nil {
+		return nil, err
+	}
+	if uint(len(data)) < t.sib {
+		t.Destroy()
+		return nil, statusBadParm.error(fmt.Sprintf("fromhost: %d bytes of data for a tensor of %d", len(data), t.sib))
+	}
+	if err = memcpy(t.mem.Ptr(), unsafe.Pointer(&data[0]), t.sib); err != nil {
+		t.Destroy()
+		return nil, err
+	}
+	return t, nil
+}
+
+var npymagic = []byte("\x93NUMPY")
+
+//WriteNpy writes t to w as a NumPy .npy file.  Strided tensors are written in packed row-major order.
+func (t *Tensor) WriteNpy(w io.Writer) error {
+	data, st, shape, err := t.packed("(*Tensor)WriteNpy()")
+	if err != nil {
+		return err
+	}
+	dims := make([]string, len(shape))
+	for i := range shape {
+		dims[i] = strconv.Itoa(int(shape[i]))
+	}
+	tuple := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		tuple += ","
+	}
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", st.npy, tuple)
+	//the header is padded with spaces and a newline so the data starts on a multiple of 64 bytes
+	pad := 64 - (len(npymagic)+4+len(header)+1)%64
+	header += strings.Repeat(" ", pad%64) + "\n"
+	var b bytes.Buffer
+	b.Write(npymagic)
+	b.Write([]byte{1, 0})
+	binary.Write(&b, binary.LittleEndian, uint16(len(header)))
+	b.WriteString(header)
+	if _, err = w.Write(b.Bytes()); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+var (
+	npydescr   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyfortran = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyshape   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+//ReadNpy reads a NumPy .npy file into a new tensor allocated with a (nil is device memory in the rocm build).
+//
+//Arrays in Fortran order get column-major strides instead of being transposed.  A 0-d array is read as shape [1].
+//Arrays of more than 2^31-1 values or 4 GiB return ErrBadParm.
+func ReadNpy(r io.Reader, a Allocator) (*Tensor, error) {
+	const op = "ReadNpy()"
+	prefix := make([]byte, len(npymagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(prefix[:len(npymagic)], npymagic) {
+		return nil, statusBadParm.error(op + ": not a .npy file")
+	}
+	var hlen int
+	switch prefix[len(npymagic)] {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		hlen = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		hlen = int(n)
+	default:
+		return nil, statusNotImplemented.error(fmt.Sprintf("%s: .npy version %d", op, prefix[len(npymagic)]))
+	}
+	if hlen > 1<<20 {
+		return nil, statusBadParm.error(fmt.Sprintf("%s: header of %d bytes", op, hlen))
+	}
+	header := make([]byte, hlen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	descr, fortran, shapem := npydescr.FindSubmatch(header), npyfortran.FindSubmatch(header), npyshape.FindSubmatch(header)
+	if descr == nil || fortran == nil || shapem == nil {
+		return nil, statusBadParm.error(fmt.Sprintf("%s: bad header %q", op, header))
+	}
+	var st serialtype
+	for _, x := range serialtypes() {
+		//int8 has no byte order and can be written as |i1 or <i1
+		if x.npy == string(descr[1]) || (x.size == 1 && x.npy[1:] == string(descr[1][1:])) {
+			st = x
+		}
+	}
+	if st.size == 0 {
+		return nil, statusNotImplemented.error(fmt.Sprintf("%s: dtype %s, only %s are read", op, descr[1], "<f4, <f2, |i1 and <i4"))
+	}
+	var shape []int32
+	for _, d := range strings.Split(string(shapem[1]), ",") {
+		if d = strings.TrimSpace(d); d == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(d, 10, 32)
+		if err != nil {
+			return nil, statusBadParm.error(fmt.Sprintf("%s: bad shape %q", op, shapem[1]))
+		}
+		shape = append(shape, int32(n))
+	}
+	if len(shape) == 0 {
+		shape = []int32{1}
+	}
+	if err := checktensorset(op, shape, nil); err != nil {
+		return nil, err
+	}
+	var stride []int32
+	if string(fortran[1]) == "True" {
+		stride = make([]int32, len(shape))
+		s := int32(1)
+		for i := range shape {
+			stride[i] = s
+			s *= shape[i]
+		}
+	}
+	_, sib, err := tensorsize(op, shape, st.size)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readfull(r, sib)
+	if err != nil {
+		return nil, err
+	}
+	return fromhost(a, st.dtype, shape, stride, data)
+}
+
+//readfull reads n bytes from r.  The buffer grows as the data is read, so a header that claims more data than
+//r has doesn't allocate all of it up front.
+func readfull(r io.Reader, n int64) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(io.LimitReader(r, n)); err != nil {
+		return nil, err
+	}
+	if int64(buf.Len()) != n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return buf.Bytes(), nil
+}
+
+//WriteNpz writes tensors to w as a NumPy .npz file, an uncompressed zip with one .npy file per name like
+//numpy.savez makes.
+func WriteNpz(w io.Writer, tensors map[string]*Tensor) error {
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if err = tensors[name].WriteNpy(f); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+//ReadNpz reads every array of a NumPy .npz file of size bytes, compressed or not.  The names don't have
+//the .npy extension.
+func ReadNpz(r io.ReaderAt, size int64, a Allocator) (map[string]*Tensor, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	tensors := make(map[string]*Tensor, len(zr.File))
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".npy") {
+			continue
+		}
+		t, err := readnpzfile(f, a)
+		if err != nil {
+			destroytensors(tensors)
+			return nil, err
+		}
+		tensors[strings.TrimSuffix(f.Name, ".npy")] = t
+	}
+	return tensors, nil
+}
+
+func readnpzfile(f *zip.File, a Allocator) (*Tensor, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ReadNpy(rc, a)
+}
+
+func destroytensors(tensors map[string]*Tensor) {
+	for _, t := range tensors {
+		t.Destroy()
+	}
+}
@@ -0,0 +1,150 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dereklstinson/half"
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestSerialization(t *testing.T) {
+	var (
+		dtype  miopen.DataType
+		format miopen.TensorFormat
+	)
+	xD, err := miopen.CreateTensorDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	//NHWC memory written in NCHW order is 1, 2, 3, 4
+	if err = xD.SetFormat(dtype.Float(), format.NHWC(), []int32{1, 2, 1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	x, err := miopen.NewTensorFrom(xD, floats{1, 3, 2, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := miopen.FromHalfSlice(nil, []int32{3}, []half.Float16{half.NewFloat16(0.5), 0, half.NewFloat16(-2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(name string, got *miopen.Tensor, shape []int32, want []float32) {
+		t.Helper()
+		vals, err := got.ToFloat32Slice()
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if !equal(got.Shape(), shape) || len(vals) != len(want) {
+			t.Fatal(name, "unexpected shape", got.Shape(), vals)
+		}
+		for i := range want {
+			if vals[i] != want[i] {
+				t.Fatal(name, "unexpected values", vals)
+			}
+		}
+	}
+	var b bytes.Buffer
+	if err = x.WriteNpy(&b); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len()%64 != 16 || !bytes.Contains(b.Bytes(), []byte("'descr': '<f4', 'fortran_order': False, 'shape': (1, 2, 1, 2), }")) {
+		t.Fatalf("unexpected header %q", b.Bytes()[:b.Len()-16])
+	}
+	got, err := miopen.ReadNpy(&b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("npy", got, []int32{1, 2, 1, 2}, []float32{1, 2, 3, 4})
+
+	//npy writes a version 1 .npy header padded to 128 bytes
+	npy := func(header string) {
+		b.Reset()
+		b.WriteString("\x93NUMPY\x01\x00\x76\x00" + header + strings.Repeat(" ", 117-len(header)) + "\n")
+	}
+	//a 2x2 array in fortran order gets column-major strides
+	npy("{'descr': '<f4', 'fortran_order': True, 'shape': (2, 2), }")
+	b.Write(bytesof(1, 3, 2, 4))
+	if got, err = miopen.ReadNpy(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	check("fortran npy", got, []int32{2, 2}, []float32{1, 2, 3, 4})
+	if !equal(got.Stride(), []int32{1, 2}) {
+		t.Error("unexpected fortran strides", got.Stride())
+	}
+
+	b.Reset()
+	if err = miopen.WriteNpz(&b, map[string]*miopen.Tensor{"x": x, "y": y}); err != nil {
+		t.Fatal(err)
+	}
+	npz, err := miopen.ReadNpz(bytes.NewReader(b.Bytes()), int64(b.Len()), miopen.HostAllocator())
+	if err != nil || len(npz) != 2 {
+		t.Fatal(npz, err)
+	}
+	check("npz x", npz["x"], []int32{1, 2, 1, 2}, []float32{1, 2, 3, 4})
+	check("npz y", npz["y"], []int32{3}, []float32{0.5, 0, -2})
+	if npz["y"].DataType() != dtype.Half() {
+		t.Error("expected npz y to be half, got", npz["y"].DataType().ToString())
+	}
+
+	b.Reset()
+	if err = miopen.WriteSafetensors(&b, map[string]*miopen.Tensor{"x": x, "y": y}, map[string]string{"format": "pt"}); err != nil {
+		t.Fatal(err)
+	}
+	st, metadata, err := miopen.ReadSafetensors(&b, nil)
+	if err != nil || len(st) != 2 || metadata["format"] != "pt" {
+		t.Fatal(st, metadata, err)
+	}
+	check("safetensors x", st["x"], []int32{1, 2, 1, 2}, []float32{1, 2, 3, 4})
+	check("safetensors y", st["y"], []int32{3}, []float32{0.5, 0, -2})
+
+	zD, err := miopen.CreateTensorDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = zD.Set(dtype.Int8x4(), []int32{1, 4, 1, 1}, nil); err != nil {
+		t.Fatal(err)
+	}
+	z, err := miopen.NewTensorFrom(zD, floats{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = z.WriteNpy(&b); !errors.Is(err, miopen.ErrNotImplemented) {
+		t.Error("expected Int8x4 to not be written, got", err)
+	}
+
+	//sizes in headers are checked before anything is allocated
+	npy("{'descr': '<f4', 'fortran_order': False, 'shape': (65536, 65536), }")
+	if _, err = miopen.ReadNpy(&b, nil); !errors.Is(err, miopen.ErrBadParm) {
+		t.Error("expected a shape that overflows an int32 to be rejected, got", err)
+	}
+	for _, header := range []string{
+		`{"x":{"dtype":"F32","shape":[1],"data_offsets":[0,4611686018427387904]}}`,
+		`{"x":{"dtype":"F32","shape":[1],"data_offsets":[1024,1028]}}`,
+	} {
+		b.Reset()
+		n := len(header)
+		b.Write([]byte{byte(n), byte(n >> 8), 0, 0, 0, 0, 0, 0})
+		b.WriteString(header)
+		b.Write(bytesof(1))
+		if _, _, err = miopen.ReadSafetensors(&b, nil); !errors.Is(err, miopen.ErrBadParm) {
+			t.Error("expected", header, "to be rejected, got", err)
+		}
+	}
+}
+
+//bytesof returns vals as little endian float32s
+func bytesof(vals ...float32) []byte {
+	b := make([]byte, 0, 4*len(vals))
+	for _, v := range vals {
+		u := math.Float32bits(v)
+		b = append(b, byte(u), byte(u>>8), byte(u>>16), byte(u>>24))
+	}
+	return b
+}
@@ -0,0 +1,73 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//Forward - Execute a forward pooling layer
+//
+//Runs forward pooling. miopenGetPoolingForwardOutputDim() should be called before
+//miopenPoolingForward().
+//If the parameter do_backward == 0, then set workSpace = nullptr and workSpaceSize = 0. However,
+//for back-propagation do_backwards must be set to 1 in miopenPoolingForward().
+//
+//h         MIOpen handle (input)
+//alpha          Floating point scaling factor, allocated on the host (input)
+//xD          Tensor descriptor for data input tensor x (input)
+//x              Data tensor x (input)
+//beta           Floating point shift factor, allocated on the host (input)
+//yD          Tensor descriptor for output data tensor y (input)
+//y              Data tensor y (output)
+//do_backward    Boolean to toggle save data in workspace for backwards pass (input)
+//wspace      Pointer user allocated memory (input)
+//wspaceSIB  Size in bytes of the memory needed (input)
+func (p *PoolingD) Forward(h *Handle, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	dobackwards bool, wspace cutil.Mem, wspaceSIB uint) error {
+	k := check{op: "(*PoolingD)Forward()"}
+	k.pooling(p, k.tensor("xD", xD, x), k.tensor("yD", yD, y))
+	err := k.err
+	if err == nil {
+		err = h.b.PoolingForward(p, alpha, xD, x, beta, yD, y, dobackwards, wspace, wspaceSIB)
+	}
+	return withdesc(err, "p", p, "xD", xD, "yD", yD)
+}
+
+//Backward - Execute a backward pooling layer
+//
+//Runs backward pooling. (p *PoolingD) GetWSpaceSize() must be called before
+//(p *PoolingD) Backward() to determine the amount of workSpace to be allocated.
+//
+//Backward pooling is always deterministic, (*Handle)SetDeterministic() doesn't change it.
+//
+//h         MIOpen handle (input)
+//alpha          Floating point scaling factor, allocated on the host (input)
+//yD          Tensor descriptor for output data tensor y (input)
+//y              Data tensor y (input)
+//dyD         Tensor descriptor for data input tensor dy (input)
+//dy             Data delta tensor dy (input)
+//xD          Tensor descriptor for output data tensor x (input)
+//x              Data tensor x (output)
+//beta           Floating point shift factor, allocated on the host (input)
+//dxD         Tensor descriptor for tensor dx (input)
+//dx             Weights delta tensor dx (output)
+//wspace      Pointer to user allocated workspace (input)
+func (p *PoolingD) Backward(h *Handle, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem) error {
+	k := check{op: "(*PoolingD)Backward()"}
+	xt, dxt := k.tensor("xD", xD, x), k.tensor("dxD", dxD, dx)
+	yt, dyt := k.tensor("yD", yD, y), k.tensor("dyD", dyD, dy)
+	k.sameshape(xt, dxt)
+	k.sameshape(yt, dyt)
+	k.pooling(p, xt, yt)
+	err := k.err
+	if err == nil {
+		err = h.b.PoolingBackward(p, alpha, yD, y, dyD, dy, xD, x, beta, dxD, dx, wspace)
+	}
+	return withdesc(err, "p", p, "yD", yD, "dyD", dyD, "xD", xD, "dxD", dxD)
+}
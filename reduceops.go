@@ -0,0 +1,80 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//The dims that are reduced are the ones where cD is 1, every other dim of cD must match aD.  ReduceShape
+//makes the shape of cD for a reduction along axes.
+
+//GetIndicesSize - Gets the size in bytes of the indices Reduce writes, 0 unless the descriptor asks for
+//flattened indices of Min, Max or AMax.
+func (r *ReduceTensorD) GetIndicesSize(h *Handle, aD, cD *TensorD) (uint, error) {
+	k := check{op: "(*ReduceTensorD)GetIndicesSize()"}
+	k.reduce(r, k.desc("aD", aD), k.desc("cD", cD))
+	if k.err != nil {
+		return 0, withdesc(k.err, "r", r, "aD", aD, "cD", cD)
+	}
+	sib, err := h.b.GetReductionIndicesSize(r, aD, cD)
+	return sib, withdesc(err, "r", r, "aD", aD, "cD", cD)
+}
+
+//GetWorkSpaceSize - Gets the size in bytes of the workspace Reduce needs
+func (r *ReduceTensorD) GetWorkSpaceSize(h *Handle, aD, cD *TensorD) (uint, error) {
+	k := check{op: "(*ReduceTensorD)GetWorkSpaceSize()"}
+	k.reduce(r, k.desc("aD", aD), k.desc("cD", cD))
+	if k.err != nil {
+		return 0, withdesc(k.err, "r", r, "aD", aD, "cD", cD)
+	}
+	sib, err := h.b.GetReductionWorkspaceSize(r, aD, cD)
+	return sib, withdesc(err, "r", r, "aD", aD, "cD", cD)
+}
+
+//Reduce - c = alpha*reduce(a) + beta*c
+//
+//	h		MIOpen handle (input)
+//	indices		Indices of the elements Min, Max and AMax picked, can be nil if indicesSIB is 0 (output)
+//	indicesSIB	Size in bytes of indices, from GetIndicesSize (input)
+//	wspace		Workspace, can be nil if wspaceSIB is 0 (input)
+//	wspaceSIB	Size in bytes of wspace, from GetWorkSpaceSize (input)
+//	alpha		Floating point scaling factor, allocated on the host (input)
+//	aD		Tensor descriptor for tensor a (input)
+//	a		Tensor a (input)
+//	beta		Floating point shift factor, allocated on the host (input)
+//	cD		Tensor descriptor for tensor c (input)
+//	c		Tensor c (output)
+func (r *ReduceTensorD) Reduce(h *Handle,
+	indices cutil.Mem, indicesSIB uint,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha float64,
+	aD *TensorD, a cutil.Mem,
+	beta float64,
+	cD *TensorD, c cutil.Mem) error {
+	k := check{op: "(*ReduceTensorD)Reduce()"}
+	k.reduce(r, k.tensor("aD", aD, a), k.tensor("cD", cD, c))
+	k.wspace(wspace, wspaceSIB)
+	if indicesSIB != 0 {
+		k.mem("indices", indices)
+	}
+	err := k.err
+	if err == nil {
+		err = h.b.ReduceTensor(r, indices, indicesSIB, wspace, wspaceSIB, alpha, aD, a, beta, cD, c)
+	}
+	return withdesc(err, "r", r, "aD", aD, "cD", cD)
+}
+
+//ReduceManaged is Reduce with a workspace from h.Workspace() sized by GetWorkSpaceSize.
+func (r *ReduceTensorD) ReduceManaged(h *Handle,
+	indices cutil.Mem, indicesSIB uint,
+	alpha float64,
+	aD *TensorD, a cutil.Mem,
+	beta float64,
+	cD *TensorD, c cutil.Mem) error {
+	wspaceSIB, err := r.GetWorkSpaceSize(h, aD, cD)
+	if err != nil {
+		return err
+	}
+	wspace, err := h.Workspace().Get(wspaceSIB)
+	if err != nil {
+		return err
+	}
+	return r.Reduce(h, indices, indicesSIB, wspace, wspaceSIB, alpha, aD, a, beta, cD, c)
+}
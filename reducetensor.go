@@ -0,0 +1,126 @@
+package miopen
+
+import "fmt"
+
+//The reduction flags have MIOpen's values written out instead of the C constants, so the package still builds
+//against headers older than Feature.ReduceTensor.
+
+//ReduceTensorOp is used for flags. Flags are set through its methods
+//
+//Reduction operations of ReduceTensorD
+type ReduceTensorOp int32
+
+//Add sets r and returns ReduceTensorOp(MIOPEN_REDUCE_TENSOR_ADD) flag
+//
+//Sum of the reduced elements
+func (r *ReduceTensorOp) Add() ReduceTensorOp { *r = ReduceTensorOp(0); return *r }
+
+//Mul sets r and returns ReduceTensorOp(MIOPEN_REDUCE_TENSOR_MUL) flag
+//
+//Product of the reduced elements
+func (r *ReduceTensorOp) Mul() ReduceTensorOp { *r = ReduceTensorOp(1); return *r }
+
+//Min sets r and returns ReduceTensorOp(MIOPEN_REDUCE_TENSOR_MIN) flag
+//
+//Smallest of the reduced elements
+func (r *ReduceTensorOp) Min() ReduceTensorOp { *r = ReduceTensorOp(2); return *r }
+
+//Max sets r and returns ReduceTensorOp(MIOPEN_REDUCE_TENSOR_MAX) flag
+//
+//Largest of the reduced elements
+func (r *ReduceTensorOp) Max() ReduceTensorOp { *r = ReduceTensorOp(3); return *r }
+
+//AMax sets r and returns ReduceTensorOp(MIOPEN_REDUCE_TENSOR_AMAX) flag
+//
+//Largest absolute value of the reduced elements
+func (r *ReduceTensorOp) AMax() ReduceTensorOp { *r = ReduceTensorOp(4); return *r }
+
+//Avg sets r and returns ReduceTensorOp(MIOPEN_REDUCE_TENSOR_AVG) flag
+//
+//Mean of the reduced elements
+func (r *ReduceTensorOp) Avg() ReduceTensorOp { *r = ReduceTensorOp(5); return *r }
+
+//Norm1 sets r and returns ReduceTensorOp(MIOPEN_REDUCE_TENSOR_NORM1) flag
+//
+//Sum of the absolute values of the reduced elements
+func (r *ReduceTensorOp) Norm1() ReduceTensorOp { *r = ReduceTensorOp(6); return *r }
+
+//Norm2 sets r and returns ReduceTensorOp(MIOPEN_REDUCE_TENSOR_NORM2) flag
+//
+//Square root of the sum of the squares of the reduced elements
+func (r *ReduceTensorOp) Norm2() ReduceTensorOp { *r = ReduceTensorOp(7); return *r }
+
+//indexed reports if r can return the indices of the elements it picked
+func (r ReduceTensorOp) indexed() bool {
+	var flg ReduceTensorOp
+	return r == flg.Min() || r == flg.Max() || r == flg.AMax()
+}
+
+//NanPropagation is used for flags. Flags are set through its methods
+type NanPropagation int32
+
+//NotPropagate sets n and returns NanPropagation(MIOPEN_NOT_PROPAGATE_NAN) flag
+func (n *NanPropagation) NotPropagate() NanPropagation { *n = NanPropagation(0); return *n }
+
+//Propagate sets n and returns NanPropagation(MIOPEN_PROPAGATE_NAN) flag
+//
+//A NaN among the elements of Min, Max or AMax makes the result NaN
+func (n *NanPropagation) Propagate() NanPropagation { *n = NanPropagation(1); return *n }
+
+//ReduceTensorIndices is used for flags. Flags are set through its methods
+type ReduceTensorIndices int32
+
+//NoIndices sets r and returns ReduceTensorIndices(MIOPEN_REDUCE_TENSOR_NO_INDICES) flag
+func (r *ReduceTensorIndices) NoIndices() ReduceTensorIndices { *r = ReduceTensorIndices(0); return *r }
+
+//Flattened sets r and returns ReduceTensorIndices(MIOPEN_REDUCE_TENSOR_FLATTENED_INDICES) flag
+//
+//Min, Max and AMax also write the index of the element they picked, counted in row-major order over the
+//reduced dims.
+func (r *ReduceTensorIndices) Flattened() ReduceTensorIndices { *r = ReduceTensorIndices(1); return *r }
+
+//IndicesType is used for flags. Flags are set through its methods
+type IndicesType int32
+
+//Int32 sets i and returns IndicesType(MIOPEN_32BIT_INDICES) flag
+func (i *IndicesType) Int32() IndicesType { *i = IndicesType(0); return *i }
+
+//Int64 sets i and returns IndicesType(MIOPEN_64BIT_INDICES) flag
+func (i *IndicesType) Int64() IndicesType { *i = IndicesType(1); return *i }
+
+//Int16 sets i and returns IndicesType(MIOPEN_16BIT_INDICES) flag
+func (i *IndicesType) Int16() IndicesType { *i = IndicesType(2); return *i }
+
+//Int8 sets i and returns IndicesType(MIOPEN_8BIT_INDICES) flag
+func (i *IndicesType) Int8() IndicesType { *i = IndicesType(3); return *i }
+
+//size returns the size in bytes of an index
+func (i IndicesType) size() uint {
+	var flg IndicesType
+	switch i {
+	case flg.Int64():
+		return 8
+	case flg.Int16():
+		return 2
+	case flg.Int8():
+		return 1
+	}
+	return 4
+}
+
+//ReduceShape returns shape with the dims in axes set to 1, the shape of the output of a reduction along axes.
+//With no axes every dim is reduced.
+func ReduceShape(shape []int32, axes ...int) ([]int32, error) {
+	if len(axes) == 0 {
+		return ones(len(shape)), nil
+	}
+	out := make([]int32, len(shape))
+	copy(out, shape)
+	for _, axis := range axes {
+		if axis < 0 || axis >= len(shape) {
+			return nil, statusBadParm.error(fmt.Sprintf("ReduceShape(): axis %d is out of range for shape %v", axis, shape))
+		}
+		out[axis] = 1
+	}
+	return out, nil
+}
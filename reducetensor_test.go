@@ -0,0 +1,103 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"unsafe"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestReduceTensor(t *testing.T) {
+	h := miopen.CreateHandle()
+	var (
+		dtype   miopen.DataType
+		op      miopen.ReduceTensorOp
+		nan     miopen.NanPropagation
+		indices miopen.ReduceTensorIndices
+		itype   miopen.IndicesType
+	)
+	a, err := miopen.FromFloat32Slice(nil, []int32{2, 3}, []float32{1, -4, 2, 3, float32(math.NaN()), -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := miopen.ReduceShape(a.Shape(), 1)
+	if err != nil || !equal(rows, []int32{2, 1}) {
+		t.Fatal("unexpected ReduceShape", rows, err)
+	}
+	c, err := miopen.NewTensor(nil, dtype.Float(), rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := miopen.CreateReduceTensorDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		op      miopen.ReduceTensorOp
+		nan     miopen.NanPropagation
+		want    []float64
+		indices []int32
+	}{
+		{op.Add(), nan.NotPropagate(), []float64{-1, math.NaN()}, nil},
+		{op.Avg(), nan.NotPropagate(), []float64{-1.0 / 3, math.NaN()}, nil},
+		{op.Norm1(), nan.NotPropagate(), []float64{7, math.NaN()}, nil},
+		{op.Max(), nan.NotPropagate(), []float64{2, 3}, []int32{2, 0}},
+		{op.Min(), nan.NotPropagate(), []float64{-4, -1}, []int32{1, 2}},
+		{op.AMax(), nan.NotPropagate(), []float64{4, 3}, []int32{1, 0}},
+		{op.Max(), nan.Propagate(), []float64{2, math.NaN()}, []int32{2, 1}},
+	} {
+		if err = r.Set(tc.op, dtype.Float(), tc.nan, indices.Flattened(), itype.Int32()); err != nil {
+			t.Fatal(err)
+		}
+		isib, err := r.GetIndicesSize(h, a.Descriptor(), c.Descriptor())
+		if err != nil || isib != uint(4*len(tc.indices)) {
+			t.Fatal("unexpected indices size", isib, err)
+		}
+		idx := make(floats, 2)
+		if err = r.ReduceManaged(h, idx, isib, 1, a.Descriptor(), a, 0, c.Descriptor(), c); err != nil {
+			t.Fatal(tc.op, err)
+		}
+		got, err := c.ToFloat32Slice()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, w := range tc.want {
+			if math.IsNaN(w) != math.IsNaN(float64(got[i])) || math.Abs(float64(got[i])-w) > 1e-6 {
+				t.Error(tc.op, tc.nan, "unexpected output", got, "want", tc.want)
+			}
+		}
+		for i, w := range tc.indices {
+			if got := *(*int32)(unsafe.Pointer(&idx[i])); got != w {
+				t.Error(tc.op, tc.nan, "unexpected index", got, "want", w)
+			}
+		}
+	}
+	all, err := miopen.FromFloat32Slice(nil, []int32{1, 1}, []float32{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := miopen.FromFloat32Slice(nil, []int32{2, 2}, []float32{3, 0, 0, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Set(op.Norm2(), dtype.Float(), nan.NotPropagate(), indices.NoIndices(), itype.Int32()); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.ReduceManaged(h, nil, 0, 1, b.Descriptor(), b, 0, all.Descriptor(), all); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := all.ToFloat32Slice(); err != nil || got[0] != 5 {
+		t.Error("unexpected Norm2", got, err)
+	}
+	if err = r.Reduce(h, nil, 0, nil, 0, 1, a.Descriptor(), a, 0, b.Descriptor(), b); !errors.Is(err, miopen.ErrBadParm) {
+		t.Error("expected [2 2] to not be a reduction of [2 3], got", err)
+	}
+	if _, err = miopen.ReduceShape(a.Shape(), 2); !errors.Is(err, miopen.ErrBadParm) {
+		t.Error("expected axis 2 to be out of range, got", err)
+	}
+}
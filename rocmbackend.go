@@ -0,0 +1,676 @@
+//go:build !nomiopen && !cpu
+// +build !nomiopen,!cpu
+
+package miopen
+
+/*
+#include <miopen/miopen.h>
+#include <miopen/version.h>
+#include <hip/hip_runtime_api.h>
+
+//migo_convbiasactivation calls miopenConvolutionBiasActivationForward if the headers have it.
+static miopenStatus_t migo_convbiasactivation(miopenHandle_t handle, const void* alpha1,
+	miopenTensorDescriptor_t xDesc, const void* x,
+	miopenTensorDescriptor_t wDesc, const void* w,
+	miopenConvolutionDescriptor_t convDesc, miopenConvFwdAlgorithm_t algo,
+	void* workspace, size_t workspaceSizeInBytes,
+	const void* alpha2,
+	miopenTensorDescriptor_t zDesc, const void* z,
+	miopenTensorDescriptor_t biasDesc, const void* bias,
+	miopenActivationDescriptor_t activationDesc,
+	miopenTensorDescriptor_t yDesc, void* y) {
+#if MIOPEN_VERSION_MAJOR > 2 || (MIOPEN_VERSION_MAJOR == 2 && MIOPEN_VERSION_MINOR >= 9)
+	return miopenConvolutionBiasActivationForward(handle, alpha1, xDesc, x, wDesc, w, convDesc, algo,
+		workspace, workspaceSizeInBytes, alpha2, zDesc, z, biasDesc, bias, activationDesc, yDesc, y);
+#else
+	return miopenStatusUnsupportedOp;
+#endif
+}
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//rocmBackend is the default Backend. It calls MIOpen with the handle it was made with.
+type rocmBackend struct {
+	x C.miopenHandle_t
+}
+
+func (r *rocmBackend) SetTensor(t *TensorD, tmem cutil.Mem, alpha float64) error {
+	dtype, _, _, err := t.Get()
+	if err != nil {
+		return err
+	}
+	val := cscalarbydatatype(dtype, alpha)
+	return Status(C.miopenSetTensor(r.x, t.d, tmem.Ptr(), val.CPtr())).error("SetAll")
+}
+
+func (r *rocmBackend) ScaleTensor(t *TensorD, tmem cutil.Mem, alpha float64) error {
+	dtype, _, _, err := t.Get()
+	if err != nil {
+		return err
+	}
+	val := cscalarbydatatype(dtype, alpha)
+	return Status(C.miopenScaleTensor(r.x, t.d, tmem.Ptr(), val.CPtr())).error("Scale")
+}
+
+//LogTensor copies t to the host and back after taking the log.  The handle's stream is synced first, so
+//the operations queued on it before are done.
+func (r *rocmBackend) LogTensor(t *TensorD, tmem cutil.Mem) error {
+	var s C.miopenAcceleratorQueue_t
+	if err := Status(C.miopenGetStream(r.x, &s)).error("Log"); err != nil {
+		return err
+	}
+	if x := C.hipStreamSynchronize(C.hipStream_t(s)); x != 0 {
+		return &Error{Status: statusInternalError, Op: fmt.Sprintf("Log: hipStreamSynchronize: hipError_t(%d)", int(x))}
+	}
+	sib, err := t.GetSIB()
+	if err != nil {
+		return err
+	}
+	b := make([]byte, sib)
+	host := &hostbuffer{b: b, p: unsafe.Pointer(&b[0])}
+	if err = memcpy(host.p, tmem.Ptr(), sib); err != nil {
+		return err
+	}
+	v, err := viewof(t, host, "Log")
+	if err != nil {
+		return err
+	}
+	vals := v.load()
+	for i := range vals {
+		vals[i] = math.Log(vals[i])
+	}
+	v.store(vals, 1, 0)
+	return memcpy(tmem.Ptr(), host.p, sib)
+}
+
+func (r *rocmBackend) TransformTensor(alpha float64, xD *TensorD, x cutil.Mem, beta float64, yD *TensorD, y cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a := cscalarbydatatype(dtype, alpha)
+	b := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenTransformTensor(r.x, a.CPtr(), xD.d, x.Ptr(), b.CPtr(), yD.d, y.Ptr())).error("TransformTensor")
+}
+
+func (r *rocmBackend) OpTensor(op OpTensorOp,
+	alpha float64, aD *TensorD, a cutil.Mem,
+	alpha2 float64, bD *TensorD, b cutil.Mem,
+	beta float64, cD *TensorD, c cutil.Mem) error {
+	dtype, _, _, err := aD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	a2 := cscalarbydatatype(dtype, alpha2)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenOpTensor(r.x, op.c(), a1.CPtr(), aD.d, a.Ptr(), a2.CPtr(), bD.d, b.Ptr(), b1.CPtr(), cD.d, c.Ptr())).error("OpTensor")
+}
+
+func (r *rocmBackend) ConvolutionForwardGetWorkSpaceSize(c *ConvolutionD, wD, xD, yD *TensorD) (wspaceSIB uint, err error) {
+	var sib C.size_t
+	err = Status(C.miopenConvolutionForwardGetWorkSpaceSize(r.x, wD.d, xD.d, c.d, yD.d, &sib)).error("GetFwdWorkSpaceSize")
+	wspaceSIB = (uint)(sib)
+	return wspaceSIB, err
+}
+
+func (r *rocmBackend) FindConvolutionForwardAlgorithm(c *ConvolutionD,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) (results []ConvFwdAlgoPerf, err error) {
+	request := (C.int)(opts.Request)
+	var actual C.int
+	results = make([]ConvFwdAlgoPerf, request)
+	err = Status(C.miopenFindConvolutionForwardAlgorithm(r.x,
+		xD.d, x.Ptr(),
+		wD.d, w.Ptr(),
+		c.d,
+		yD.d, y.Ptr(),
+		request, &actual, results[0].cptr(),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB),
+		(C.bool)(!opts.NoExhaustiveSearch))).error("FindForwardAlgorithm")
+	return results[:actual], err
+}
+
+func (r *rocmBackend) ConvolutionForward(c *ConvolutionD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvFwdAlgorithm,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenConvolutionForward(r.x, a1.CPtr(), xD.d, x.Ptr(), wD.d, w.Ptr(), c.d, algo.c(), b1.CPtr(), yD.d, y.Ptr(), wspaceptr(wspace), (C.size_t)(wspaceSIB))).error("(c *ConvolutionD)Forward()")
+}
+
+func (r *rocmBackend) ConvolutionForwardBias(c *ConvolutionD, alpha float64,
+	bD *TensorD, b cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	dtype, _, _, err := bD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenConvolutionForwardBias(
+		r.x,
+		a1.CPtr(),
+		bD.d, b.Ptr(),
+		b1.CPtr(),
+		yD.d, y.Ptr())).error("(c *ConvolutionD)ForwardBias()")
+}
+
+func (r *rocmBackend) ConvolutionBiasActivationForward(c *ConvolutionD, alpha1 float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvFwdAlgorithm,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha2 float64,
+	zD *TensorD, z cutil.Mem,
+	bD *TensorD, b cutil.Mem,
+	a *ActivationD,
+	yD *TensorD, y cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha1)
+	a2 := cscalarbydatatype(dtype, alpha2)
+	var zd C.miopenTensorDescriptor_t
+	if zD != nil {
+		zd = zD.d
+	}
+	return Status(C.migo_convbiasactivation(r.x, a1.CPtr(), xD.d, x.Ptr(), wD.d, w.Ptr(), c.d, algo.c(),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB), a2.CPtr(), zd, wspaceptr(z),
+		bD.d, b.Ptr(), a.d, yD.d, y.Ptr())).error("(c *ConvolutionD)ForwardBiasActivation()")
+}
+
+func (r *rocmBackend) ConvolutionBackwardDataGetWorkSpaceSize(c *ConvolutionD, dyD, wD, dxD *TensorD) (wspaceSIB uint, err error) {
+	var wspace C.size_t
+	err = Status(C.miopenConvolutionBackwardDataGetWorkSpaceSize(r.x, dyD.d, wD.d, c.d, dxD.d, &wspace)).error("(c *ConvolutionD)GetBackwardDataWorkSpaceSize")
+	wspaceSIB = (uint)(wspace)
+	return wspaceSIB, err
+}
+
+func (r *rocmBackend) FindConvolutionBackwardDataAlgorithm(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) (results []ConvBwdDataAlgoPerf, err error) {
+	request := (C.int)(opts.Request)
+	var actual C.int
+	results = make([]ConvBwdDataAlgoPerf, request)
+	err = Status(C.miopenFindConvolutionBackwardDataAlgorithm(r.x,
+		dyD.d, dy.Ptr(),
+		wD.d, w.Ptr(),
+		c.d,
+		dxD.d, dx.Ptr(),
+		request, &actual, results[0].cptr(),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB),
+		(C.bool)(!opts.NoExhaustiveSearch))).error("(c *ConvolutionD)FindBwdDataAlgorithm")
+	return results[:actual], err
+}
+
+func (r *rocmBackend) ConvolutionBackwardData(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvBwdDataAlgorithm,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	dtype, _, _, err := dyD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenConvolutionBackwardData(r.x,
+		a1.CPtr(),
+		dyD.d, dy.Ptr(),
+		wD.d, w.Ptr(),
+		c.d,
+		algo.c(),
+		b1.CPtr(),
+		dxD.d, dx.Ptr(),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB))).error("(c *ConvolutionD)BackwardData()")
+}
+
+func (r *rocmBackend) ConvolutionBackwardWeightsGetWorkSpaceSize(c *ConvolutionD, dyD, xD, dwD *TensorD) (wspaceSIB uint, err error) {
+	var wspace C.size_t
+	err = Status(C.miopenConvolutionBackwardWeightsGetWorkSpaceSize(r.x, dyD.d, xD.d, c.d, dwD.d, &wspace)).error("GetBwdWeightsWorkspaceSize")
+	wspaceSIB = (uint)(wspace)
+	return wspaceSIB, err
+}
+
+func (r *rocmBackend) FindConvolutionBackwardWeightsAlgorithm(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) (results []ConvBwdWeightAlgoPerf, err error) {
+	request := (C.int)(opts.Request)
+	var actual C.int
+	results = make([]ConvBwdWeightAlgoPerf, request)
+	err = Status(C.miopenFindConvolutionBackwardWeightsAlgorithm(r.x,
+		dyD.d, dy.Ptr(),
+		xD.d, x.Ptr(),
+		c.d,
+		dwD.d, dw.Ptr(),
+		request, &actual, results[0].cptr(),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB),
+		(C.bool)(!opts.NoExhaustiveSearch))).error("FindBwdWeightsAlgorithm")
+	return results[:actual], err
+}
+
+func (r *rocmBackend) ConvolutionBackwardWeights(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	algo ConvBwdWeightsAlgorithm,
+	beta float64,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenConvolutionBackwardWeights(r.x,
+		a1.CPtr(),
+		dyD.d, dy.Ptr(),
+		xD.d, x.Ptr(),
+		c.d,
+		algo.c(),
+		b1.CPtr(),
+		dwD.d, dw.Ptr(),
+		wspaceptr(wspace), (C.size_t)(wspaceSIB))).error("(c *ConvolutionD)BackwardWeights()")
+}
+
+func (r *rocmBackend) ConvolutionBackwardBias(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	beta float64,
+	dbD *TensorD, db cutil.Mem) error {
+	dtype, _, _, err := dyD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenConvolutionBackwardBias(
+		r.x,
+		a1.CPtr(),
+		dyD.d, dy.Ptr(),
+		b1.CPtr(),
+		dbD.d, db.Ptr())).error("(c *ConvolutionD)BackwardBias()")
+}
+
+func (r *rocmBackend) ActivationForward(a *ActivationD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenActivationForward(r.x, a.d, a1.CPtr(), xD.d, x.Ptr(), b1.CPtr(), yD.d, y.Ptr())).error("(a *Activation)Forward()")
+}
+
+func (r *rocmBackend) ActivationBackward(a *ActivationD, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenActivationBackward(r.x, a.d, a1.CPtr(),
+		yD.d, y.Ptr(),
+		dyD.d, dy.Ptr(),
+		xD.d, x.Ptr(), b1.CPtr(), dxD.d, dx.Ptr())).error("(a *Activation)Backward()")
+}
+
+func (r *rocmBackend) PoolingForward(p *PoolingD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	dobackwards bool, wspace cutil.Mem, wspaceSIB uint) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return errors.New(err.Error() + " in (*Pooling)Forward()")
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenPoolingForward(r.x, p.d, a1.CPtr(),
+		xD.d, x.Ptr(),
+		b1.CPtr(),
+		yD.d, y.Ptr(),
+		(C.bool)(dobackwards), memptr(wspace), (C.size_t)(wspaceSIB))).error("(*Pooling)Forward()")
+}
+
+func (r *rocmBackend) PoolingBackward(p *PoolingD, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return errors.New(err.Error() + " in (*Pooling)Backward()")
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenPoolingBackward(r.x, p.d, a1.CPtr(), yD.d, y.Ptr(), dyD.d, dy.Ptr(), xD.d, x.Ptr(), b1.CPtr(), dxD.d, dx.Ptr(), memptr(wspace))).error("(*PoolingD)Backward()")
+}
+
+func (r *rocmBackend) SoftmaxForward(alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenSoftmaxForward(r.x, a1.CPtr(), xD.d, x.Ptr(), b1.CPtr(), yD.d, y.Ptr())).error("(s *SoftMaxD)Forward()")
+}
+
+func (r *rocmBackend) SoftmaxBackward(alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	dtype, _, _, err := yD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha).CPtr()
+	b1 := cscalarbydatatype(dtype, beta).CPtr()
+	return Status(C.miopenSoftmaxBackward(r.x, a1, yD.d, y.Ptr(), dyD.d, dy.Ptr(), b1, dxD.d, dx.Ptr())).error("(s *SoftMaxD)Backward()")
+}
+
+func (r *rocmBackend) LRNForward(l *LRND, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	doBackwards bool, wspace cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return errors.New(err.Error() + " in (l *LRND)Forward()")
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenLRNForward(r.x, l.d, a1.CPtr(), xD.d, x.Ptr(), b1.CPtr(), yD.d, y.Ptr(), (C.bool)(doBackwards), memptr(wspace))).error(" (l *LRND)Forward()")
+}
+
+func (r *rocmBackend) LRNBackward(l *LRND, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem) error {
+	dtype, _, _, err := yD.Get()
+	if err != nil {
+		return errors.New(err.Error() + " in (l *LRND)Backward()")
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	return Status(C.miopenLRNBackward(r.x, l.d, a1.CPtr(), yD.d, y.Ptr(), dyD.d, dy.Ptr(), xD.d, x.Ptr(), b1.CPtr(), dxD.d, dx.Ptr(), memptr(wspace))).error("(l *LRND)Backward()")
+}
+
+func (r *rocmBackend) BatchNormalizationForwardInference(b *BatchNormD, alpha, beta float64,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	scalbiasmeanvarD *TensorD,
+	scale, bias cutil.Mem,
+	mean, variance cutil.Mem,
+	epsilon float64) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	var meanptr, varptr unsafe.Pointer
+	if mean != nil && variance != nil {
+		meanptr = mean.Ptr()
+		varptr = variance.Ptr()
+	}
+	return Status(C.miopenBatchNormalizationForwardInference(r.x, b.mode, a1.CPtr(), b1.CPtr(),
+		xD.d, x.Ptr(),
+		yD.d, y.Ptr(),
+		scalbiasmeanvarD.d,
+		scale.Ptr(), bias.Ptr(),
+		meanptr, varptr,
+		(C.double)(epsilon))).error("(b *BatchNormD)ForwardInference")
+}
+
+func (r *rocmBackend) BatchNormalizationForwardTraining(b *BatchNormD, alpha, beta float64,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	scalbiasmeanvarD *TensorD,
+	scale, bias cutil.Mem,
+	avgfactor float64,
+	mean, variance cutil.Mem,
+	epsilon float64,
+	saveMean, saveInvariance cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alpha)
+	b1 := cscalarbydatatype(dtype, beta)
+	var (
+		meanptr            unsafe.Pointer
+		varptr             unsafe.Pointer
+		savemeanptr        unsafe.Pointer
+		saveinvvarianceptr unsafe.Pointer
+	)
+	if mean != nil && variance != nil {
+		meanptr = mean.Ptr()
+		varptr = variance.Ptr()
+	}
+	if saveMean != nil && saveInvariance != nil {
+		savemeanptr = saveMean.Ptr()
+		saveinvvarianceptr = saveInvariance.Ptr()
+	}
+	return Status(C.miopenBatchNormalizationForwardTraining(r.x, b.mode, a1.CPtr(), b1.CPtr(),
+		xD.d, x.Ptr(),
+		yD.d, y.Ptr(),
+		scalbiasmeanvarD.d,
+		scale.Ptr(), bias.Ptr(),
+		(C.double)(avgfactor),
+		meanptr, varptr,
+		(C.double)(epsilon),
+		savemeanptr, saveinvvarianceptr)).error("(b *BatchNormD)ForwardTraining")
+}
+
+func (r *rocmBackend) BatchNormalizationBackward(b *BatchNormD, alphaDataDiff, betaDataDiff, alphaParamDiff, betaParamDiff float64,
+	xD *TensorD, x cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	scalebiasdiffD *TensorD,
+	scale, scalediff, biasdiff cutil.Mem,
+	epsilon float64,
+	savedMean, savedInvVariance cutil.Mem) error {
+	dtype, _, _, err := xD.Get()
+	if err != nil {
+		return err
+	}
+	a1 := cscalarbydatatype(dtype, alphaDataDiff)
+	b1 := cscalarbydatatype(dtype, betaDataDiff)
+	a2 := cscalarbydatatype(dtype, alphaParamDiff)
+	b2 := cscalarbydatatype(dtype, betaParamDiff)
+	var meanptr, invvarptr unsafe.Pointer
+	if savedMean != nil && savedInvVariance != nil {
+		meanptr = savedMean.Ptr()
+		invvarptr = savedInvVariance.Ptr()
+	}
+	return Status(C.miopenBatchNormalizationBackward(r.x, b.mode, a1.CPtr(), b1.CPtr(), a2.CPtr(), b2.CPtr(), xD.d, x.Ptr(), dyD.d, dy.Ptr(), dxD.d, dx.Ptr(), scalebiasdiffD.d,
+		scale.Ptr(), scalediff.Ptr(), biasdiff.Ptr(), (C.double)(epsilon), meanptr, invvarptr)).error("(b *BatchNormD)Backward()")
+}
+
+//memptr returns the pointer of m or nil if m is nil. It is used for optional workspaces.
+func memptr(m cutil.Mem) unsafe.Pointer {
+	if m == nil {
+		return nil
+	}
+	return m.Ptr()
+}
+
+//wspaceptr returns nil for a nil workspace, which is passed when the workspace size is 0.
+func wspaceptr(wspace cutil.Mem) unsafe.Pointer {
+	if wspace == nil {
+		return nil
+	}
+	return wspace.Ptr()
+}
+
+func convsolutions(s []C.miopenConvSolution_t) []ConvSolution {
+	solutions := make([]ConvSolution, len(s))
+	for i := range s {
+		solutions[i] = ConvSolution{
+			ID:            uint64(s[i].solution_id),
+			Time:          float32(s[i].time),
+			WorkspaceSize: uint(s[i].workspace_size),
+			Algorithm:     ConvAlgorithm(s[i].algorithm),
+		}
+	}
+	return solutions
+}
+
+func (r *rocmBackend) ConvolutionForwardGetSolutionCount(c *ConvolutionD, wD, xD, yD *TensorD) (uint, error) {
+	var count C.size_t
+	err := Status(C.miopenConvolutionForwardGetSolutionCount(r.x, wD.d, xD.d, c.d, yD.d, &count)).error("(*ConvolutionD)GetFwdSolutionCount()")
+	return uint(count), err
+}
+
+func (r *rocmBackend) ConvolutionForwardGetSolution(c *ConvolutionD, wD, xD, yD *TensorD, max uint) ([]ConvSolution, error) {
+	if max == 0 {
+		return nil, nil
+	}
+	var count C.size_t
+	solutions := make([]C.miopenConvSolution_t, max)
+	err := Status(C.miopenConvolutionForwardGetSolution(r.x, wD.d, xD.d, c.d, yD.d, C.size_t(max), &count, &solutions[0])).error("(*ConvolutionD)GetFwdSolutions()")
+	return convsolutions(solutions[:count]), err
+}
+
+func (r *rocmBackend) ConvolutionForwardGetSolutionWorkspaceSize(c *ConvolutionD, wD, xD, yD *TensorD, solutionID uint64) (uint, error) {
+	var wspaceSIB C.size_t
+	err := Status(C.miopenConvolutionForwardGetSolutionWorkspaceSize(r.x, wD.d, xD.d, c.d, yD.d, C.uint64_t(solutionID), &wspaceSIB)).error("(*ConvolutionD)GetFwdSolutionWorkspaceSize()")
+	return uint(wspaceSIB), err
+}
+
+func (r *rocmBackend) ConvolutionForwardCompileSolution(c *ConvolutionD, wD, xD, yD *TensorD, solutionID uint64) error {
+	return Status(C.miopenConvolutionForwardCompileSolution(r.x, wD.d, xD.d, c.d, yD.d, C.uint64_t(solutionID))).error("(*ConvolutionD)CompileFwdSolution()")
+}
+
+func (r *rocmBackend) ConvolutionForwardImmediate(c *ConvolutionD,
+	wD *TensorD, w cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	return Status(C.miopenConvolutionForwardImmediate(r.x,
+		wD.d, w.Ptr(), xD.d, x.Ptr(), c.d, yD.d, y.Ptr(),
+		wspaceptr(wspace), C.size_t(wspaceSIB), C.uint64_t(solutionID))).error("(*ConvolutionD)ForwardImmediate()")
+}
+
+func (r *rocmBackend) ConvolutionBackwardDataGetSolutionCount(c *ConvolutionD, dyD, wD, dxD *TensorD) (uint, error) {
+	var count C.size_t
+	err := Status(C.miopenConvolutionBackwardDataGetSolutionCount(r.x, dyD.d, wD.d, c.d, dxD.d, &count)).error("(*ConvolutionD)GetBwdDataSolutionCount()")
+	return uint(count), err
+}
+
+func (r *rocmBackend) ConvolutionBackwardDataGetSolution(c *ConvolutionD, dyD, wD, dxD *TensorD, max uint) ([]ConvSolution, error) {
+	if max == 0 {
+		return nil, nil
+	}
+	var count C.size_t
+	solutions := make([]C.miopenConvSolution_t, max)
+	err := Status(C.miopenConvolutionBackwardDataGetSolution(r.x, dyD.d, wD.d, c.d, dxD.d, C.size_t(max), &count, &solutions[0])).error("(*ConvolutionD)GetBwdDataSolutions()")
+	return convsolutions(solutions[:count]), err
+}
+
+func (r *rocmBackend) ConvolutionBackwardDataGetSolutionWorkspaceSize(c *ConvolutionD, dyD, wD, dxD *TensorD, solutionID uint64) (uint, error) {
+	var wspaceSIB C.size_t
+	err := Status(C.miopenConvolutionBackwardDataGetSolutionWorkspaceSize(r.x, dyD.d, wD.d, c.d, dxD.d, C.uint64_t(solutionID), &wspaceSIB)).error("(*ConvolutionD)GetBwdDataSolutionWorkspaceSize()")
+	return uint(wspaceSIB), err
+}
+
+func (r *rocmBackend) ConvolutionBackwardDataCompileSolution(c *ConvolutionD, dyD, wD, dxD *TensorD, solutionID uint64) error {
+	return Status(C.miopenConvolutionBackwardDataCompileSolution(r.x, dyD.d, wD.d, c.d, dxD.d, C.uint64_t(solutionID))).error("(*ConvolutionD)CompileBwdDataSolution()")
+}
+
+func (r *rocmBackend) ConvolutionBackwardDataImmediate(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	return Status(C.miopenConvolutionBackwardDataImmediate(r.x,
+		dyD.d, dy.Ptr(), wD.d, w.Ptr(), c.d, dxD.d, dx.Ptr(),
+		wspaceptr(wspace), C.size_t(wspaceSIB), C.uint64_t(solutionID))).error("(*ConvolutionD)BackwardDataImmediate()")
+}
+
+func (r *rocmBackend) ConvolutionBackwardWeightsGetSolutionCount(c *ConvolutionD, dyD, xD, dwD *TensorD) (uint, error) {
+	var count C.size_t
+	err := Status(C.miopenConvolutionBackwardWeightsGetSolutionCount(r.x, dyD.d, xD.d, c.d, dwD.d, &count)).error("(*ConvolutionD)GetBwdWeightsSolutionCount()")
+	return uint(count), err
+}
+
+func (r *rocmBackend) ConvolutionBackwardWeightsGetSolution(c *ConvolutionD, dyD, xD, dwD *TensorD, max uint) ([]ConvSolution, error) {
+	if max == 0 {
+		return nil, nil
+	}
+	var count C.size_t
+	solutions := make([]C.miopenConvSolution_t, max)
+	err := Status(C.miopenConvolutionBackwardWeightsGetSolution(r.x, dyD.d, xD.d, c.d, dwD.d, C.size_t(max), &count, &solutions[0])).error("(*ConvolutionD)GetBwdWeightsSolutions()")
+	return convsolutions(solutions[:count]), err
+}
+
+func (r *rocmBackend) ConvolutionBackwardWeightsGetSolutionWorkspaceSize(c *ConvolutionD, dyD, xD, dwD *TensorD, solutionID uint64) (uint, error) {
+	var wspaceSIB C.size_t
+	err := Status(C.miopenConvolutionBackwardWeightsGetSolutionWorkspaceSize(r.x, dyD.d, xD.d, c.d, dwD.d, C.uint64_t(solutionID), &wspaceSIB)).error("(*ConvolutionD)GetBwdWeightsSolutionWorkspaceSize()")
+	return uint(wspaceSIB), err
+}
+
+func (r *rocmBackend) ConvolutionBackwardWeightsCompileSolution(c *ConvolutionD, dyD, xD, dwD *TensorD, solutionID uint64) error {
+	return Status(C.miopenConvolutionBackwardWeightsCompileSolution(r.x, dyD.d, xD.d, c.d, dwD.d, C.uint64_t(solutionID))).error("(*ConvolutionD)CompileBwdWeightsSolution()")
+}
+
+func (r *rocmBackend) ConvolutionBackwardWeightsImmediate(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	return Status(C.miopenConvolutionBackwardWeightsImmediate(r.x,
+		dyD.d, dy.Ptr(), xD.d, x.Ptr(), c.d, dwD.d, dw.Ptr(),
+		wspaceptr(wspace), C.size_t(wspaceSIB), C.uint64_t(solutionID))).error("(*ConvolutionD)BackwardWeightsImmediate()")
+}
@@ -0,0 +1,168 @@
+package miopen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//safetensorsentry is the header entry of one tensor in a safetensors file.
+type safetensorsentry struct {
+	DType       string   `json:"dtype"`
+	Shape       []int32  `json:"shape"`
+	DataOffsets [2]int64 `json:"data_offsets"`
+}
+
+//WriteSafetensors writes tensors to w in the safetensors format, with metadata (which can be nil) under
+//"__metadata__".  Tensors are written in name order, strided tensors in packed row-major order.
+func WriteSafetensors(w io.Writer, tensors map[string]*Tensor, metadata map[string]string) error {
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		if name == "__metadata__" {
+			return statusBadParm.error("WriteSafetensors(): __metadata__ can't be the name of a tensor")
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	header := make(map[string]interface{}, len(tensors)+1)
+	if len(metadata) > 0 {
+		header["__metadata__"] = metadata
+	}
+	var data bytes.Buffer
+	for _, name := range names {
+		b, st, shape, err := tensors[name].packed("WriteSafetensors()")
+		if err != nil {
+			return err
+		}
+		start := int64(data.Len())
+		data.Write(b)
+		header[name] = safetensorsentry{DType: st.safetensors, Shape: shape, DataOffsets: [2]int64{start, int64(data.Len())}}
+	}
+	hb, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	//the header is padded with spaces so the data is 8 byte aligned
+	hb = append(hb, bytes.Repeat([]byte{' '}, (8-len(hb)%8)%8)...)
+	if err = binary.Write(w, binary.LittleEndian, uint64(len(hb))); err != nil {
+		return err
+	}
+	if _, err = w.Write(hb); err != nil {
+		return err
+	}
+	_, err = w.Write(data.Bytes())
+	return err
+}
+
+//ReadSafetensors reads every tensor of a safetensors file into tensors allocated with a, and returns them
+//with the metadata of the file.  Tensors of more than 2^31-1 values or 4 GiB, and data_offsets that don't
+//match the shapes, return ErrBadParm.
+func ReadSafetensors(r io.Reader, a Allocator) (tensors map[string]*Tensor, metadata map[string]string, err error) {
+	const op = "ReadSafetensors()"
+	var hlen uint64
+	if err = binary.Read(r, binary.LittleEndian, &hlen); err != nil {
+		return nil, nil, err
+	}
+	if hlen > 100<<20 {
+		return nil, nil, statusBadParm.error(fmt.Sprintf("%s: header of %d bytes", op, hlen))
+	}
+	hb := make([]byte, hlen)
+	if _, err = io.ReadFull(r, hb); err != nil {
+		return nil, nil, err
+	}
+	var header map[string]json.RawMessage
+	if err = json.Unmarshal(hb, &header); err != nil {
+		return nil, nil, statusBadParm.error(fmt.Sprintf("%s: bad header: %v", op, err))
+	}
+	if raw, ok := header["__metadata__"]; ok {
+		if err = json.Unmarshal(raw, &metadata); err != nil {
+			return nil, nil, statusBadParm.error(fmt.Sprintf("%s: bad __metadata__: %v", op, err))
+		}
+		delete(header, "__metadata__")
+	}
+	entries := make(map[string]safetensorsentry, len(header))
+	var end, total int64
+	for name, raw := range header {
+		var e safetensorsentry
+		if err = json.Unmarshal(raw, &e); err != nil {
+			return nil, nil, statusBadParm.error(fmt.Sprintf("%s: bad entry %s: %v", op, name, err))
+		}
+		sib, err := e.check(op, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if e.DataOffsets[1] > end {
+			end = e.DataOffsets[1]
+		}
+		total += sib
+		entries[name] = e
+	}
+	//the data of the tensors can't have holes, so it ends at the sum of their sizes
+	if end > total {
+		return nil, nil, statusBadParm.error(fmt.Sprintf("%s: data_offsets end at %d, the tensors are %d bytes", op, end, total))
+	}
+	data, err := readfull(r, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	tensors = make(map[string]*Tensor, len(entries))
+	for name, e := range entries {
+		t, err := safetensorsfrom(op, name, e, data, a)
+		if err != nil {
+			destroytensors(tensors)
+			return nil, nil, err
+		}
+		tensors[name] = t
+	}
+	return tensors, metadata, nil
+}
+
+//check returns the size in bytes of e after checking its dtype, and its shape against its data_offsets
+func (e safetensorsentry) check(op, name string) (sib int64, err error) {
+	st, shape, err := e.serialtype(op, name)
+	if err != nil {
+		return 0, err
+	}
+	if err = checktensorset(op, shape, nil); err != nil {
+		return 0, err
+	}
+	if _, sib, err = tensorsize(op, shape, st.size); err != nil {
+		return 0, err
+	}
+	start, stop := e.DataOffsets[0], e.DataOffsets[1]
+	if start < 0 || stop < start || stop-start != sib {
+		return 0, statusBadParm.error(fmt.Sprintf("%s: %s has data_offsets %v for shape %v", op, name, e.DataOffsets, e.Shape))
+	}
+	return sib, nil
+}
+
+//serialtype returns the serialtype of e's dtype and its shape, which is [1] for a scalar
+func (e safetensorsentry) serialtype(op, name string) (serialtype, []int32, error) {
+	var st serialtype
+	for _, x := range serialtypes() {
+		if strings.EqualFold(x.safetensors, e.DType) {
+			st = x
+		}
+	}
+	if st.size == 0 {
+		return st, nil, statusNotImplemented.error(fmt.Sprintf("%s: %s has dtype %s, only F32, F16, I8 and I32 are read", op, name, e.DType))
+	}
+	shape := e.Shape
+	if len(shape) == 0 {
+		shape = []int32{1}
+	}
+	return st, shape, nil
+}
+
+//safetensorsfrom makes the tensor of e, which has been checked, out of data
+func safetensorsfrom(op, name string, e safetensorsentry, data []byte, a Allocator) (*Tensor, error) {
+	st, shape, err := e.serialtype(op, name)
+	if err != nil {
+		return nil, err
+	}
+	return fromhost(a, st.dtype, shape, nil, data[e.DataOffsets[0]:e.DataOffsets[1]])
+}
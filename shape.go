@@ -0,0 +1,136 @@
+package miopen
+
+import "fmt"
+
+//ConvolutionGeometry holds everything needed to work out the output shape of a convolution without MIOpen.
+//
+//It lets network shapes be planned, and checked, on machines without a device.
+type ConvolutionGeometry struct {
+	Pad, Stride, Dilation []int32
+	Mode                  ConvolutionMode
+	Groups                int32       //Groups less than 1 are treated as 1
+	OutputPadding         []int32     //OutputPadding is only used by transpose convolution. nil is all zeros.
+	PaddingMode           PaddingMode //With Same or Valid, Pad is ignored
+}
+
+//Geometry returns the geometry c was set with, including the group count and transpose output padding.
+//
+//The PaddingMode is always Default since it can't be read back from MIOpen.
+func (c *ConvolutionD) Geometry() (g ConvolutionGeometry, err error) {
+	g.Pad, g.Stride, g.Dilation, g.Mode, err = c.Get()
+	if err != nil {
+		return g, err
+	}
+	g.Groups = c.groupcount()
+	g.OutputPadding = c.outputpadding()
+	return g, nil
+}
+
+//OutputDim returns the shape of y for an input shaped x and weights shaped w. It is the go version of
+//(*ConvolutionD)ForwardOutputDim().
+//
+//	x is [N,C,spatial...]
+//	w is [K,C/groups,spatial...] for convolution and [C,K/groups,spatial...] for transpose convolution
+//
+//With k = dilation*(w-1) + 1 each spatial dim of y is:
+//
+//	             Default                                      Same             Valid
+//	convolution: (x + 2*pad - k)/stride + 1                   ceil(x/stride)   ceil((x-k+1)/stride)
+//	transpose:   stride*(x-1) - 2*pad + k + outputpadding     x*stride         stride*(x-1) + k
+func (g ConvolutionGeometry) OutputDim(x, w []int32) ([]int32, error) {
+	const op = "(ConvolutionGeometry)OutputDim()"
+	var (
+		mflg ConvolutionMode
+		pflg PaddingMode
+	)
+	spatial := len(g.Pad)
+	if g.PaddingMode != pflg.Default() {
+		spatial = len(g.Stride)
+	}
+	if spatial == 0 || len(g.Stride) != spatial || len(g.Dilation) != spatial {
+		return nil, statusBadParm.error(fmt.Sprintf("%s: pad %v, stride %v and dilation %v must have the same non zero length", op, g.Pad, g.Stride, g.Dilation))
+	}
+	if len(x) != spatial+2 || len(w) != len(x) {
+		return nil, statusBadParm.error(fmt.Sprintf("%s: x %v and w %v must have %d dims", op, x, w, spatial+2))
+	}
+	adj := g.OutputPadding
+	if adj == nil {
+		adj = make([]int32, spatial)
+	}
+	if len(adj) != spatial {
+		return nil, statusBadParm.error(fmt.Sprintf("%s: output padding %v must have %d dims", op, adj, spatial))
+	}
+	groups := g.Groups
+	if groups < 1 {
+		groups = 1
+	}
+	transpose := g.Mode == mflg.Transpose()
+	y := make([]int32, len(x))
+	y[0] = x[0]
+	if transpose {
+		if x[1] != w[0] || w[0]%groups != 0 {
+			return nil, statusBadParm.error(fmt.Sprintf("%s: transpose x %v channels must equal w[0] of %v and be divisible by %d groups", op, x, w, groups))
+		}
+		y[1] = w[1] * groups
+	} else {
+		if x[1] != w[1]*groups || w[0]%groups != 0 {
+			return nil, statusBadParm.error(fmt.Sprintf("%s: x %v channels must equal w[1]*groups of w %v and %d groups", op, x, w, groups))
+		}
+		y[1] = w[0]
+	}
+	for i := 0; i < spatial; i++ {
+		if g.Stride[i] < 1 || g.Dilation[i] < 1 {
+			return nil, statusBadParm.error(fmt.Sprintf("%s: stride %v and dilation %v must be positive", op, g.Stride, g.Dilation))
+		}
+		in, s := x[i+2], g.Stride[i]
+		k := g.Dilation[i]*(w[i+2]-1) + 1
+		switch {
+		case transpose && g.PaddingMode == pflg.Same():
+			y[i+2] = in * s
+		case transpose && g.PaddingMode == pflg.Valid():
+			y[i+2] = s*(in-1) + k
+		case transpose:
+			y[i+2] = s*(in-1) - 2*g.Pad[i] + k + adj[i]
+		case g.PaddingMode == pflg.Same():
+			y[i+2] = (in + s - 1) / s
+		case g.PaddingMode == pflg.Valid():
+			y[i+2] = (in - k + s) / s
+			if in < k {
+				y[i+2] = 0
+			}
+		default:
+			y[i+2] = (in+2*g.Pad[i]-k)/s + 1
+			if in+2*g.Pad[i] < k {
+				y[i+2] = 0
+			}
+		}
+		if y[i+2] < 1 {
+			return nil, statusBadParm.error(fmt.Sprintf("%s: filter %v is larger than the padded input %v", op, w, x))
+		}
+	}
+	return y, nil
+}
+
+//PoolingOutputDim returns the shape of y for an input shaped x.  It is the go version of
+//(*PoolingD)GetForwardOutputDim(), and works for any number of spatial dims.
+//
+//	y = max(1, (x + 2*pad - window)/stride + 1)
+func PoolingOutputDim(x, window, pad, stride []int32) ([]int32, error) {
+	const op = "PoolingOutputDim()"
+	spatial := len(window)
+	if spatial == 0 || len(pad) != spatial || len(stride) != spatial || len(x) != spatial+2 {
+		return nil, statusBadParm.error(fmt.Sprintf("%s: x %v must have 2 more dims than window %v, pad %v and stride %v", op, x, window, pad, stride))
+	}
+	y := make([]int32, len(x))
+	y[0], y[1] = x[0], x[1]
+	for i := 0; i < spatial; i++ {
+		if stride[i] < 1 {
+			return nil, statusBadParm.error(fmt.Sprintf("%s: stride %v must be positive", op, stride))
+		}
+		y[i+2] = (x[i+2]+2*pad[i]-window[i])/stride[i] + 1
+		if y[i+2] < 1 {
+			y[i+2] = 1
+		}
+	}
+	return y, nil
+}
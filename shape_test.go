@@ -0,0 +1,139 @@
+package miopen_test
+
+import (
+	"errors"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+//TestConvolutionGeometry checks the go shape inference against (*ConvolutionD)ForwardOutputDim(), which calls
+//MIOpen in the rocm build.
+func TestConvolutionGeometry(t *testing.T) {
+	var mode miopen.ConvolutionMode
+	var dtype miopen.DataType
+	for _, tc := range []struct {
+		x, w                  []int32
+		pad, stride, dilation []int32
+		transpose             bool
+		groups                int32
+		adj                   []int32
+		want                  []int32
+	}{
+		{x: []int32{1, 3, 7, 7}, w: []int32{8, 3, 3, 3}, pad: []int32{1, 1}, stride: []int32{2, 2}, dilation: []int32{1, 1}, want: []int32{1, 8, 4, 4}},
+		{x: []int32{2, 4, 9, 9}, w: []int32{6, 2, 3, 3}, pad: []int32{0, 0}, stride: []int32{1, 1}, dilation: []int32{2, 2}, groups: 2, want: []int32{2, 6, 5, 5}},
+		{x: []int32{1, 2, 5, 6, 7}, w: []int32{4, 2, 1, 3, 3}, pad: []int32{0, 1, 1}, stride: []int32{1, 1, 2}, dilation: []int32{1, 1, 1}, want: []int32{1, 4, 5, 6, 4}},
+		{x: []int32{1, 8, 4, 4}, w: []int32{8, 3, 3, 3}, pad: []int32{1, 1}, stride: []int32{2, 2}, dilation: []int32{1, 1}, transpose: true, adj: []int32{1, 0}, want: []int32{1, 3, 8, 7}},
+	} {
+		c, err := miopen.CreateConvolutionDescriptor()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := mode.Convolution()
+		if tc.transpose {
+			m = mode.Transpose()
+		}
+		if err = c.Set(tc.pad, tc.stride, tc.dilation, m); err != nil {
+			t.Fatal(err)
+		}
+		if tc.groups > 1 {
+			if err = c.SetGroupCount(tc.groups); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tc.adj != nil {
+			if err = c.SetTransposeOutputPadding(tc.adj); err != nil {
+				t.Fatal(err)
+			}
+		}
+		g, err := c.Geometry()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := g.OutputDim(tc.x, tc.w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equal(got, tc.want) {
+			t.Error("OutputDim", tc.x, tc.w, "got", got, "want", tc.want)
+		}
+		xD, wD := descriptor(t, dtype.Float(), tc.x), descriptor(t, dtype.Float(), tc.w)
+		fromdesc, err := c.ForwardOutputDim(xD, wD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equal(got, fromdesc) {
+			t.Error("OutputDim", got, "differs from ForwardOutputDim", fromdesc)
+		}
+	}
+}
+
+func TestPaddingModes(t *testing.T) {
+	var mode miopen.ConvolutionMode
+	var pmode miopen.PaddingMode
+	g := miopen.ConvolutionGeometry{Stride: []int32{2, 3}, Dilation: []int32{1, 1}, Mode: mode.Convolution(), PaddingMode: pmode.Same()}
+	x, w := []int32{1, 3, 7, 8}, []int32{4, 3, 3, 3}
+	if y, err := g.OutputDim(x, w); err != nil || !equal(y, []int32{1, 4, 4, 3}) {
+		t.Error("Same", y, err)
+	}
+	g.PaddingMode = pmode.Valid()
+	if y, err := g.OutputDim(x, w); err != nil || !equal(y, []int32{1, 4, 3, 2}) {
+		t.Error("Valid", y, err)
+	}
+}
+
+func TestTransposeGroups(t *testing.T) {
+	var mode miopen.ConvolutionMode
+	g := miopen.ConvolutionGeometry{Pad: []int32{0, 0}, Stride: []int32{1, 1}, Dilation: []int32{1, 1}, Mode: mode.Transpose(), Groups: 3}
+	if y, err := g.OutputDim([]int32{1, 2, 3, 3}, []int32{2, 1, 1, 1}); !errors.Is(err, miopen.ErrBadParm) {
+		t.Error("expected w[0] not divisible by the groups to be rejected, got", y, err)
+	}
+}
+
+func TestPoolingOutputDim(t *testing.T) {
+	var mode miopen.PoolingMode
+	var dtype miopen.DataType
+	p, err := miopen.CreatePoolingDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	window, pad, stride := []int32{3, 2}, []int32{1, 0}, []int32{2, 2}
+	if err = p.Set(mode.Max(), window, pad, stride); err != nil {
+		t.Fatal(err)
+	}
+	x := []int32{2, 3, 9, 5}
+	got, err := miopen.PoolingOutputDim(x, window, pad, stride)
+	if err != nil || !equal(got, []int32{2, 3, 5, 2}) {
+		t.Fatal("PoolingOutputDim", got, err)
+	}
+	fromdesc, err := p.GetForwardOutputDim(descriptor(t, dtype.Float(), x))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equal(got, fromdesc) {
+		t.Error("PoolingOutputDim", got, "differs from GetForwardOutputDim", fromdesc)
+	}
+}
+
+func descriptor(t *testing.T, dtype miopen.DataType, shape []int32) *miopen.TensorD {
+	tD, err := miopen.CreateTensorDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tD.Set(dtype, shape, nil); err != nil {
+		t.Fatal(err)
+	}
+	return tD
+}
+
+func equal(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//Forward - Execute a softmax forward layer
+//
+//	h		MIOpen handle (input)
+//	alpha		Floating point scaling factor, allocated on the host (input)
+//	xD		Tensor descriptor for data input tensor x (input)
+//	x		Data tensor x (input)
+//	beta		Floating point shift factor, allocated on the host (input)
+//	yD		Tensor descriptor for output data tensor y (input)
+//	y		Data tensor y (output)
+func (s *SoftMaxD) Forward(h *Handle, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+) error {
+	k := check{op: "(*SoftMaxD)Forward()"}
+	xt, yt := k.tensor("xD", xD, x), k.tensor("yD", yD, y)
+	k.sameshape(xt, yt)
+	k.samedtype(xt, yt)
+	err := k.err
+	if err == nil {
+		err = h.b.SoftmaxForward(alpha, xD, x, beta, yD, y)
+	}
+	return withdesc(err, "xD", xD, "yD", yD)
+}
+
+//Backward - Execute a softmax backwards layer
+//
+//	h		MIOpen handle (input)
+//	alpha		Floating point scaling factor, allocated on the host (input)
+//	yD		Tensor descriptor for input data tensor y (input)
+//	y		Data tensor y (input)
+//	dyD		Tensor descriptor for input data tensor dy (input)
+//	dy		Data delta tensor dy (input)
+//	beta		Floating point shift factor, allocated on the host (input)
+//	dxD		Tensor descriptor for data output tensor dx (input)
+//	dx		Output data delta tensor dx (output)
+func (s *SoftMaxD) Backward(h *Handle, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	k := check{op: "(*SoftMaxD)Backward()"}
+	yt, dyt, dxt := k.tensor("yD", yD, y), k.tensor("dyD", dyD, dy), k.tensor("dxD", dxD, dx)
+	k.sameshape(yt, dyt, dxt)
+	k.samedtype(yt, dyt, dxt)
+	err := k.err
+	if err == nil {
+		err = h.b.SoftmaxBackward(alpha, yD, y, dyD, dy, beta, dxD, dx)
+	}
+	return withdesc(err, "yD", yD, "dyD", dyD, "dxD", dxD)
+}
@@ -0,0 +1,229 @@
+package miopen
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+	"github.com/dereklstinson/half"
+)
+
+//Tensor is a TensorD together with the memory it describes.  It implements cutil.Mem, so it can be passed
+//with its Descriptor() to any operation.
+//
+//	x, err := miopen.FromFloat32Slice(nil, []int32{1, 3, 2, 2}, data)
+//	err = a.Forward(h, 1, x.Descriptor(), x, 0, y.Descriptor(), y)
+//	out, err := y.ToFloat32Slice()
+//
+//The memory comes from an Allocator.  A nil Allocator is device memory in the rocm build and go memory in the cpu
+//build, and HostAllocator() is go memory in both.
+type Tensor struct {
+	d   *TensorD
+	mem cutil.Mem
+	sib uint
+	a   Allocator //a is nil if the Tensor doesn't own d and mem
+	lifetime
+}
+
+//NewTensor allocates a packed tensor shaped dims.  The memory isn't zeroed.
+func NewTensor(a Allocator, dtype DataType, dims []int32) (*Tensor, error) {
+	return newtensor(a, dtype, dims, nil)
+}
+
+//NewTensorFrom makes a Tensor out of a descriptor and memory that are already set up, so they can be used
+//with the functions that take a Tensor.  The Tensor doesn't own them, and its Destroy leaves them alone.
+func NewTensorFrom(tD *TensorD, m cutil.Mem) (*Tensor, error) {
+	k := check{op: "NewTensorFrom()"}
+	k.tensor("tD", tD, m)
+	if k.err != nil {
+		return nil, k.err
+	}
+	sib, err := tD.GetSIB()
+	if err != nil {
+		return nil, err
+	}
+	t := &Tensor{d: tD, mem: m, sib: sib}
+	t.start("Tensor")
+	return t, nil
+}
+
+//newtensor allocates a tensor shaped dims with stride.  A nil stride is packed.
+func newtensor(a Allocator, dtype DataType, dims, stride []int32) (*Tensor, error) {
+	if a == nil {
+		a = defaultallocator()
+	}
+	d, err := CreateTensorDescriptor()
+	if err != nil {
+		return nil, err
+	}
+	if err = d.Set(dtype, dims, stride); err != nil {
+		d.Destroy()
+		return nil, err
+	}
+	sib, err := d.GetSIB()
+	if err != nil {
+		d.Destroy()
+		return nil, err
+	}
+	mem, err := a.Allocate(sib)
+	if err != nil {
+		d.Destroy()
+		return nil, err
+	}
+	t := &Tensor{d: d, mem: mem, sib: sib, a: a}
+	runtime.SetFinalizer(t, (*Tensor).Destroy)
+	t.start("Tensor")
+	return t, nil
+}
+
+//FromFloat32Slice allocates a Float tensor shaped dims and copies data into it.
+func FromFloat32Slice(a Allocator, dims []int32, data []float32) (*Tensor, error) {
+	var dtype DataType
+	if err := checkvolume("FromFloat32Slice()", dims, len(data), 4); err != nil {
+		return nil, err
+	}
+	t, err := NewTensor(a, dtype.Float(), dims)
+	if err != nil {
+		return nil, err
+	}
+	if err = memcpy(t.mem.Ptr(), unsafe.Pointer(&data[0]), t.sib); err != nil {
+		t.Destroy()
+		return nil, err
+	}
+	return t, nil
+}
+
+//FromHalfSlice allocates a Half tensor shaped dims and copies data into it.
+func FromHalfSlice(a Allocator, dims []int32, data []half.Float16) (*Tensor, error) {
+	var dtype DataType
+	if err := checkvolume("FromHalfSlice()", dims, len(data), 2); err != nil {
+		return nil, err
+	}
+	t, err := NewTensor(a, dtype.Half(), dims)
+	if err != nil {
+		return nil, err
+	}
+	if err = memcpy(t.mem.Ptr(), unsafe.Pointer(&data[0]), t.sib); err != nil {
+		t.Destroy()
+		return nil, err
+	}
+	return t, nil
+}
+
+func checkvolume(op string, dims []int32, n, size int) error {
+	if err := checktensorset(op, dims, nil); err != nil {
+		return err
+	}
+	v, _, err := tensorsize(op, dims, size)
+	if err != nil {
+		return err
+	}
+	if v != int64(n) {
+		return statusBadParm.error(fmt.Sprintf("%s: dims %v hold %d values, data has %d", op, dims, v, n))
+	}
+	return nil
+}
+
+//maxtensorSIB is the largest tensor made from a go slice or read from a file
+const maxtensorSIB = 1 << 32
+
+//tensorsize returns the volume of dims and its size in bytes with elements of size bytes.  It is worked out
+//in int64, and fails if the volume doesn't fit the int32 the descriptors use or the size is over maxtensorSIB.
+//dims must already be positive.
+func tensorsize(op string, dims []int32, size int) (volume, sib int64, err error) {
+	volume = 1
+	for _, d := range dims {
+		volume *= int64(d)
+		if volume > math.MaxInt32 {
+			return 0, 0, statusBadParm.error(fmt.Sprintf("%s: dims %v hold more than %d values", op, dims, math.MaxInt32))
+		}
+	}
+	sib = volume * int64(size)
+	if sib > maxtensorSIB {
+		return 0, 0, statusBadParm.error(fmt.Sprintf("%s: dims %v are %d bytes, more than %d", op, dims, sib, int64(maxtensorSIB)))
+	}
+	return volume, sib, nil
+}
+
+//ToFloat32Slice copies the tensor to go and returns its values in packed NCHW order.  Float, Half, Int32 and
+//Int8 tensors are converted to float32.
+func (t *Tensor) ToFloat32Slice() ([]float32, error) {
+	host, err := t.host()
+	if err != nil {
+		return nil, err
+	}
+	v, err := viewof(t.d, host, "(*Tensor)ToFloat32Slice()")
+	if err != nil {
+		return nil, err
+	}
+	vals := v.load()
+	out := make([]float32, len(vals))
+	for i := range vals {
+		out[i] = float32(vals[i])
+	}
+	return out, nil
+}
+
+//host copies the tensor's memory to go
+func (t *Tensor) host() (*hostbuffer, error) {
+	b := make([]byte, t.sib)
+	if err := memcpy(unsafe.Pointer(&b[0]), t.mem.Ptr(), t.sib); err != nil {
+		return nil, err
+	}
+	return &hostbuffer{b: b, p: unsafe.Pointer(&b[0])}, nil
+}
+
+//Descriptor returns the tensor's descriptor.  Changing its shape or data type would no longer match the
+//memory, so it should only be read.
+func (t *Tensor) Descriptor() *TensorD { return t.d }
+
+//Ptr returns the pointer to the tensor's memory
+func (t *Tensor) Ptr() unsafe.Pointer { return t.mem.Ptr() }
+
+//DPtr returns the double pointer to the tensor's memory
+func (t *Tensor) DPtr() *unsafe.Pointer { return t.mem.DPtr() }
+
+//SIB returns the size in bytes of the tensor's memory
+func (t *Tensor) SIB() uint { return t.sib }
+
+//DataType returns the data type of the tensor
+func (t *Tensor) DataType() DataType {
+	dtype, _, _, _ := t.d.Get()
+	return dtype
+}
+
+//Shape returns the dims of the tensor
+func (t *Tensor) Shape() []int32 {
+	_, shape, _, _ := t.d.Get()
+	return shape
+}
+
+//Stride returns the strides of the tensor
+func (t *Tensor) Stride() []int32 {
+	_, _, stride, _ := t.d.Get()
+	return stride
+}
+
+//Rank returns the number of dims of the tensor
+func (t *Tensor) Rank() int { return len(t.Shape()) }
+
+//Volume returns the number of values in the tensor
+func (t *Tensor) Volume() int { return int(findvolume(t.Shape())) }
+
+//Destroy frees the memory and the descriptor now instead of when the tensor is garbage collected.  It is safe
+//to call more than once, but the tensor can't be used after.  A Tensor made by NewTensorFrom frees nothing.
+func (t *Tensor) Destroy() error {
+	runtime.SetFinalizer(t, nil)
+	if t.a == nil {
+		return t.end(nil)
+	}
+	return t.end(func() error {
+		err := t.a.Free(t.mem)
+		if derr := t.d.Destroy(); err == nil {
+			err = derr
+		}
+		return err
+	})
+}
@@ -0,0 +1,66 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dereklstinson/half"
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestTensor(t *testing.T) {
+	h := miopen.CreateHandle()
+	a, err := miopen.CreateActivationDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mode miopen.ActivationMode
+	if err = a.Set(mode.Relu(), 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	dims := []int32{1, 2, 1, 2}
+	for _, alloc := range []miopen.Allocator{nil, miopen.HostAllocator()} {
+		x, err := miopen.FromFloat32Slice(alloc, dims, []float32{-1, 2, -3, 4})
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := miopen.FromHalfSlice(alloc, dims, []half.Float16{half.NewFloat16(0.5), 0, 0, half.NewFloat16(-2)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equal(y.Shape(), dims) || y.Volume() != 4 || y.SIB() != 8 || y.DataType() != new(miopen.DataType).Half() {
+			t.Fatal("unexpected shape", y.Shape(), y.Volume(), y.SIB(), y.DataType().ToString())
+		}
+		yf, err := miopen.NewTensor(alloc, x.DataType(), dims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = a.Forward(h, 1, x.Descriptor(), x, 0, yf.Descriptor(), yf); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := y.ToFloat32Slice(); err != nil || got[0] != 0.5 || got[3] != -2 {
+			t.Fatal("unexpected half values", got, err)
+		}
+		got, err := yf.ToFloat32Slice()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range []float32{0, 2, 0, 4} {
+			if got[i] != v {
+				t.Fatal("unexpected output", got)
+			}
+		}
+		if err = x.Destroy(); err != nil {
+			t.Fatal(err)
+		}
+		if err = x.Destroy(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = miopen.FromFloat32Slice(nil, dims, make([]float32, 3)); !errors.Is(err, miopen.ErrBadParm) {
+		t.Error("expected the volume of dims to be checked, got", err)
+	}
+}
@@ -0,0 +1,119 @@
+package miopen
+
+import "fmt"
+
+//TensorFormat is used as flags for the order a tensor's dims are laid out in memory.  The shape of a TensorD is
+//always in NCHW order (N, C, then the spatial dims), the format only changes the strides.
+//
+//	var f miopen.TensorFormat
+//	err := xD.SetFormat(dtype.Float(), f.NHWC(), []int32{n, c, h, w})
+type TensorFormat int32
+
+//NCHW sets f and returns the NCHW flag.  Channels first, the packed row-major layout Set uses with a nil stride.
+func (f *TensorFormat) NCHW() TensorFormat { *f = TensorFormat(0); return *f }
+
+//NHWC sets f and returns the NHWC flag.  Channels last.
+func (f *TensorFormat) NHWC() TensorFormat { *f = TensorFormat(1); return *f }
+
+//CHWN sets f and returns the CHWN flag.  Batch last.
+func (f *TensorFormat) CHWN() TensorFormat { *f = TensorFormat(2); return *f }
+
+//All returns every TensorFormat flag
+func (f TensorFormat) All() []TensorFormat {
+	var x TensorFormat
+	return []TensorFormat{x.NCHW(), x.NHWC(), x.CHWN()}
+}
+
+func (f TensorFormat) String() string {
+	var x TensorFormat
+	switch f {
+	case x.NCHW():
+		return "NCHW"
+	case x.NHWC():
+		return "NHWC"
+	case x.CHWN():
+		return "CHWN"
+	}
+	return fmt.Sprintf("TensorFormat(%d)", int32(f))
+}
+
+//order returns the dims of a rank dim tensor from the outermost to the innermost in memory
+func (f TensorFormat) order(rank int) []int {
+	var x TensorFormat
+	order := make([]int, 0, rank)
+	switch f {
+	case x.NHWC():
+		order = append(order, 0)
+		for i := 2; i < rank; i++ {
+			order = append(order, i)
+		}
+		return append(order, 1)
+	case x.CHWN():
+		for i := 1; i < rank; i++ {
+			order = append(order, i)
+		}
+		return append(order, 0)
+	}
+	for i := 0; i < rank; i++ {
+		order = append(order, i)
+	}
+	return order
+}
+
+//strides returns the packed strides of a tensor with dims in NCHW order laid out in format f
+func (f TensorFormat) strides(dims []int32) []int32 {
+	order := f.order(len(dims))
+	strides := make([]int32, len(dims))
+	stride := int32(1)
+	for i := len(order) - 1; i >= 0; i-- {
+		strides[order[i]] = stride
+		stride *= dims[order[i]]
+	}
+	return strides
+}
+
+//SetFormat sets t to a packed tensor with dims in NCHW order (N, C, then the spatial dims) laid out in memory
+//in format.  It works out the strides so they don't have to be passed to Set.
+//
+//	dims		N, C, H, W or N, C, D, H, W (input)
+func (t *TensorD) SetFormat(dtype DataType, format TensorFormat, dims []int32) error {
+	const op = "(*TensorD)SetFormat()"
+	if len(dims) < 3 {
+		return statusBadParm.error(fmt.Sprintf("%s: dims %v must be N, C and at least one spatial dim", op, dims))
+	}
+	if format.name() == "" {
+		return statusBadParm.error(fmt.Sprintf("%s: %s is not a TensorFormat", op, format))
+	}
+	if err := checktensorset(op, dims, nil); err != nil {
+		return err
+	}
+	return t.Set(dtype, dims, format.strides(dims))
+}
+
+//name is the name of a known format and "" otherwise
+func (f TensorFormat) name() string {
+	for _, x := range f.All() {
+		if x == f {
+			return x.String()
+		}
+	}
+	return ""
+}
+
+//Format returns the TensorFormat whose packed strides t has.  A tensor with dims of 1 can match more than
+//one format, the first in All() is returned.  It returns an error if t isn't packed in any of them.
+func (t *TensorD) Format() (TensorFormat, error) {
+	_, shape, stride, err := t.Get()
+	if err != nil {
+		return 0, err
+	}
+	if len(shape) >= 3 {
+		var f TensorFormat
+		for _, format := range f.All() {
+			if comparedims(format.strides(shape), stride) {
+				return format, nil
+			}
+		}
+	}
+	return 0, statusBadParm.error(fmt.Sprintf("(*TensorD)Format(): shape %v with stride %v is not a packed TensorFormat", shape, stride))
+}
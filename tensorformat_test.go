@@ -0,0 +1,45 @@
+package miopen_test
+
+import (
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestTensorFormat(t *testing.T) {
+	var (
+		f     miopen.TensorFormat
+		dtype miopen.DataType
+	)
+	dims := []int32{2, 3, 4, 5}
+	for _, tc := range []struct {
+		format miopen.TensorFormat
+		stride []int32
+	}{
+		{f.NCHW(), []int32{60, 20, 5, 1}},
+		{f.NHWC(), []int32{60, 1, 15, 3}},
+		{f.CHWN(), []int32{1, 40, 10, 2}},
+	} {
+		tD, err := miopen.CreateTensorDescriptor()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = tD.SetFormat(dtype.Float(), tc.format, dims); err != nil {
+			t.Fatal(err)
+		}
+		_, shape, stride, err := tD.Get()
+		if err != nil || !equal(shape, dims) || !equal(stride, tc.stride) {
+			t.Error(tc.format, "shape", shape, "stride", stride, err)
+		}
+		if got, err := tD.Format(); err != nil || got != tc.format {
+			t.Error("Format of", tc.format, "is", got, err)
+		}
+	}
+	tD := descriptor(t, dtype.Float(), []int32{2, 3, 4})
+	if err := tD.Set(dtype.Float(), []int32{2, 3, 4}, []int32{24, 8, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tD.Format(); err == nil {
+		t.Error("a strided tensor has a format")
+	}
+}
@@ -0,0 +1,86 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//SetAll - Fills a tensor with a single value.
+//
+func (t *TensorD) SetAll(h *Handle, tmem cutil.Mem, alpha float64) error {
+	k := check{op: "(t *TensorD)SetAll()"}
+	k.tensor("t", t, tmem)
+	err := k.err
+	if err == nil {
+		err = h.b.SetTensor(t, tmem, alpha)
+	}
+	return withdesc(err, "t", t)
+}
+
+//Scale - Scales all elements in a tensor by a single value.
+//
+//	h		MiOpen handle (input)
+//
+//	tmem		Tensor Memory (input and output)
+//
+//	alpha		Floating point scaling factor, allocated on the host (input)
+//
+func (t *TensorD) Scale(h *Handle, tmem cutil.Mem, alpha float64) error {
+	k := check{op: "(t *TensorD)Scale()"}
+	k.tensor("t", t, tmem)
+	err := k.err
+	if err == nil {
+		err = h.b.ScaleTensor(t, tmem, alpha)
+	}
+	return withdesc(err, "t", t)
+}
+
+//TransformTensor - Copies one tensor to another tensor with a different layout.
+//
+//	h         MIOpen handle (input)
+//
+//	alpha     Floating point scaling factor, allocated on the host (input)
+//
+//	xD	      Source Tensor descriptor for tensor x (input)
+//
+//	x         Source Tensor x (input)
+//
+//	beta       Floating point scaling factor, allocated on the host (input)
+//
+//	yD	      Destination Tensor descriptor for tensor y (input)
+//
+//	y         Destination Tensor y (output)
+//
+func TransformTensor(h *Handle, alpha float64, xD *TensorD, x cutil.Mem, beta float64, yD *TensorD, y cutil.Mem) error {
+	k := check{op: "TransformTensor()"}
+	xt, yt := k.tensor("xD", xD, x), k.tensor("yD", yD, y)
+	k.rank(yt, len(xt.shape))
+	k.sameshape(xt, yt)
+	err := k.err
+	if err == nil {
+		err = h.b.TransformTensor(alpha, xD, x, beta, yD, y)
+	}
+	return withdesc(err, "xD", xD, "yD", yD)
+}
+
+//OpTensor - This function implements:  C = op ( alpha1[0] * A, alpha2[0] * B ) + beta[0] * C
+//
+//A and C must have the same shape, B is broadcast to it.
+//
+//For Forward Bias one can also use, miopenConvolutionForwardBias()
+func OpTensor(h *Handle,
+	op OpTensorOp,
+	alpha float64,
+	aD *TensorD, a cutil.Mem,
+	alpha2 float64,
+	bD *TensorD, b cutil.Mem,
+	beta float64,
+	cD *TensorD, c cutil.Mem) error {
+	k := check{op: "OpTensor()"}
+	at, bt, ct := k.tensor("aD", aD, a), k.tensor("bD", bD, b), k.tensor("cD", cD, c)
+	k.samedtype(ct, at, bt)
+	k.sameshape(at, ct)
+	k.broadcast(bt, ct)
+	err := k.err
+	if err == nil {
+		err = h.b.OpTensor(op, alpha, aD, a, alpha2, bD, b, beta, cD, c)
+	}
+	return withdesc(err, "aD", aD, "bD", bD, "cD", cD)
+}
@@ -0,0 +1,417 @@
+package miopen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//Call is one operation that went through a Tracer.
+//
+//Args hold the contents of every descriptor (read through their Get methods), the scalars, and the size in bytes of every
+//buffer (null for nil buffers).  Results hold the returned workspace sizes and algorithm perfs so a trace can be replayed
+//without a device.
+type Call struct {
+	Func    string    `json:"func"`
+	Args    []CallArg `json:"args"`
+	Results []CallArg `json:"results,omitempty"`
+	Err     string    `json:"err,omitempty"`
+	Error   *Error    `json:"error,omitempty"` //Error is set instead of Err when the call returned an *Error
+}
+
+//CallArg is a named argument or result of a Call encoded as JSON.
+type CallArg struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+//String returns the call as a single line of JSON without its results.
+func (c Call) String() string {
+	b, err := json.Marshal(Call{Func: c.Func, Args: c.Args})
+	if err != nil {
+		return c.Func
+	}
+	return string(b)
+}
+
+//same compares the function and the arguments of two calls.
+func (c Call) same(x Call) bool {
+	if c.Func != x.Func || len(c.Args) != len(x.Args) {
+		return false
+	}
+	for i := range c.Args {
+		if c.Args[i].Name != x.Args[i].Name || !jsonequal(c.Args[i].Value, x.Args[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonequal(a, b json.RawMessage) bool {
+	var ab, bb bytes.Buffer
+	if json.Compact(&ab, a) != nil || json.Compact(&bb, b) != nil {
+		return false
+	}
+	return bytes.Equal(ab.Bytes(), bb.Bytes())
+}
+
+//ReplayError is returned by a replaying Tracer when a call doesn't match the trace.
+type ReplayError struct {
+	Index int  //Index of the call in the trace
+	Want  Call //Want is the zero Call when the trace had already ended
+	Got   Call
+}
+
+func (r *ReplayError) Error() string {
+	if r.Want.Func == "" {
+		return fmt.Sprintf("replay: call %d past the end of the trace: %v", r.Index, r.Got)
+	}
+	return fmt.Sprintf("replay: call %d differs from the trace:\n\twant %v\n\tgot  %v", r.Index, r.Want, r.Got)
+}
+
+//Tracer is a Backend that records or replays every call made through it.
+//
+//A recording Tracer passes each call to the backend it wraps and appends it to its trace.  A replaying Tracer checks each
+//call against a trace made earlier.  Once a call doesn't match, that call and every call after it return a *ReplayError.
+//
+//Tracers are safe to share between goroutines, but a trace is only reproducible if the calls are made in the same order.
+type Tracer struct {
+	mux    sync.Mutex
+	next   Backend
+	replay bool
+	trace  []Call
+	pos    int
+	err    error
+}
+
+//NewRecorder returns a Tracer that records every call and then passes it to next.
+func NewRecorder(next Backend) *Tracer {
+	return &Tracer{next: next}
+}
+
+//NewReplayer returns a Tracer that checks every call against trace.
+//
+//If next is nil the calls are not run. Buffers are never touched, and workspace sizes, algorithm perfs and errors
+//are returned as they were recorded. This lets the code that builds the descriptors be tested on machines without a device.
+//If next isn't nil, calls that match the trace are passed to it.
+func NewReplayer(trace []Call, next Backend) *Tracer {
+	return &Tracer{next: next, replay: true, trace: trace}
+}
+
+//Record wraps the backend of h with a recording Tracer and returns it.
+func (h *Handle) Record() *Tracer {
+	t := NewRecorder(h.b)
+	h.b = t
+	return t
+}
+
+//Replay replaces the backend of h with a Tracer that checks calls against trace without running them.
+//
+//Use (*Tracer)Done() at the end of the run to check that the whole trace was replayed, and SetBackend(nil) to
+//restore the default backend.
+func (h *Handle) Replay(trace []Call) *Tracer {
+	t := NewReplayer(trace, nil)
+	h.b = t
+	return t
+}
+
+//Trace returns a copy of the calls recorded, or the trace being replayed.
+func (t *Tracer) Trace() []Call {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return append([]Call(nil), t.trace...)
+}
+
+//Done returns the first mismatch found by a replaying Tracer, or an error if the trace wasn't replayed to its end.
+//
+//For a recording Tracer it always returns nil.
+func (t *Tracer) Done() error {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if !t.replay || t.err != nil {
+		return t.err
+	}
+	if t.pos != len(t.trace) {
+		return fmt.Errorf("replay: %d of %d calls made, next is %v", t.pos, len(t.trace), t.trace[t.pos])
+	}
+	return nil
+}
+
+//WriteTrace writes the trace of t as indented JSON to w.
+func (t *Tracer) WriteTrace(w io.Writer) error {
+	b, err := json.MarshalIndent(t.Trace(), "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+//ReadTrace reads a trace written by (*Tracer)WriteTrace.
+func ReadTrace(r io.Reader) ([]Call, error) {
+	var trace []Call
+	err := json.NewDecoder(r).Decode(&trace)
+	return trace, err
+}
+
+//result is a pointer to a value returned by a backend method and its name in the trace.
+type result struct {
+	name string
+	ptr  interface{}
+}
+
+//do records or replays c.  run makes the call with the backend it is given and fills the results.
+func (t *Tracer) do(c Call, run func(b Backend) error, results ...result) error {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if !t.replay {
+		if t.next == nil {
+			return errors.New("Tracer: nil backend")
+		}
+		err := run(t.next)
+		for _, r := range results {
+			c.Results = append(c.Results, CallArg{Name: r.name, Value: mustjson(r.ptr)})
+		}
+		if e, ok := err.(*Error); ok {
+			c.Error = e
+		} else if err != nil {
+			c.Err = err.Error()
+		}
+		t.trace = append(t.trace, c)
+		return err
+	}
+	if t.err != nil {
+		return t.err
+	}
+	if t.pos >= len(t.trace) {
+		t.err = &ReplayError{Index: t.pos, Got: c}
+		return t.err
+	}
+	want := t.trace[t.pos]
+	if !want.same(c) {
+		t.err = &ReplayError{Index: t.pos, Want: want, Got: c}
+		return t.err
+	}
+	t.pos++
+	if t.next != nil {
+		return run(t.next)
+	}
+	for _, r := range results {
+		for _, w := range want.Results {
+			if w.Name != r.name {
+				continue
+			}
+			if err := json.Unmarshal(w.Value, r.ptr); err != nil {
+				return err
+			}
+		}
+	}
+	if want.Error != nil {
+		e := *want.Error
+		return &e
+	}
+	if want.Err != "" {
+		return errors.New(want.Err)
+	}
+	return nil
+}
+
+func mustjson(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(err.Error())
+	}
+	return b
+}
+
+func newcall(fn string, args ...CallArg) Call {
+	return Call{Func: fn, Args: args}
+}
+
+//arg encodes a scalar.
+func arg(name string, v interface{}) CallArg {
+	return CallArg{Name: name, Value: mustjson(v)}
+}
+
+//memarg records the size in bytes that d describes, or null if m is nil.
+func memarg(name string, m cutil.Mem, d *TensorD) CallArg {
+	if m == nil {
+		return arg(name, nil)
+	}
+	var sib uint
+	if d != nil {
+		sib, _ = d.GetSIB()
+	}
+	return arg(name, sib)
+}
+
+//wspacearg records wspaceSIB, or null if wspace is nil.
+func wspacearg(name string, wspace cutil.Mem, wspaceSIB uint) CallArg {
+	if wspace == nil {
+		return arg(name, nil)
+	}
+	return arg(name, wspaceSIB)
+}
+
+//descarg records the contents of a descriptor through its Get method. Unset descriptors are recorded with the error
+//returned by Get.
+func descarg(name string, d interface{}) CallArg {
+	var (
+		v   interface{}
+		err error
+	)
+	switch d := d.(type) {
+	case *TensorD:
+		if d == nil {
+			break
+		}
+		var t struct {
+			DataType DataType `json:"dtype"`
+			Shape    []int32  `json:"shape"`
+			Stride   []int32  `json:"stride"`
+		}
+		t.DataType, t.Shape, t.Stride, err = d.Get()
+		v = t
+	case *ConvolutionD:
+		if d == nil {
+			break
+		}
+		var c struct {
+			Pad           []int32         `json:"pad"`
+			Stride        []int32         `json:"stride"`
+			Dilation      []int32         `json:"dilation"`
+			Mode          ConvolutionMode `json:"mode"`
+			Groups        int32           `json:"groups"`
+			OutputPadding []int32         `json:"outputpadding"`
+		}
+		c.Pad, c.Stride, c.Dilation, c.Mode, err = d.Get()
+		c.Groups, c.OutputPadding = d.groupcount(), d.outputpadding()
+		v = c
+	case *PoolingD:
+		if d == nil {
+			break
+		}
+		var p struct {
+			Mode   PoolingMode `json:"mode"`
+			Window []int32     `json:"window"`
+			Pad    []int32     `json:"pad"`
+			Stride []int32     `json:"stride"`
+		}
+		p.Mode, p.Window, p.Pad, p.Stride, err = d.Get()
+		v = p
+	case *ActivationD:
+		if d == nil {
+			break
+		}
+		var a struct {
+			Mode  ActivationMode `json:"mode"`
+			Alpha float64        `json:"alpha"`
+			Beta  float64        `json:"beta"`
+			Gamma float64        `json:"gamma"`
+		}
+		a.Mode, a.Alpha, a.Beta, a.Gamma, err = d.Get()
+		v = a
+	case *LRND:
+		if d == nil {
+			break
+		}
+		var l struct {
+			Mode  LRNMode `json:"mode"`
+			N     uint32  `json:"n"`
+			Alpha float64 `json:"alpha"`
+			Beta  float64 `json:"beta"`
+			K     float64 `json:"k"`
+		}
+		l.Mode, l.N, l.Alpha, l.Beta, l.K, err = d.Get()
+		v = l
+	case *BatchNormD:
+		if d == nil {
+			break
+		}
+		var b struct {
+			Mode BatchNormMode `json:"mode"`
+		}
+		b.Mode, err = d.Get()
+		v = b
+	case *ReduceTensorD:
+		if d == nil {
+			break
+		}
+		var r struct {
+			Op      ReduceTensorOp      `json:"op"`
+			Comp    DataType            `json:"comp"`
+			Nan     NanPropagation      `json:"nan"`
+			Indices ReduceTensorIndices `json:"indices"`
+			IType   IndicesType         `json:"itype"`
+		}
+		r.Op, r.Comp, r.Nan, r.Indices, r.IType, err = d.Get()
+		v = r
+	}
+	if err != nil {
+		v = map[string]string{"error": err.Error()}
+	}
+	return arg(name, v)
+}
+
+//traceperf is how algorithm perfs are written in a trace or a FindCache.
+type traceperf struct {
+	Algo   int32   `json:"algo"`
+	Time   float32 `json:"time"`
+	Memory uint    `json:"memory"`
+}
+
+func fwdtraceperfs(ps []ConvFwdAlgoPerf) []traceperf {
+	perfs := make([]traceperf, len(ps))
+	for i := range ps {
+		algo, time, mem := ps[i].Get()
+		perfs[i] = traceperf{Algo: int32(algo), Time: time, Memory: mem}
+	}
+	return perfs
+}
+
+func fwdperfs(perfs []traceperf) []ConvFwdAlgoPerf {
+	ps := make([]ConvFwdAlgoPerf, len(perfs))
+	for i, p := range perfs {
+		ps[i] = newConvFwdAlgoPerf(ConvFwdAlgorithm(p.Algo), p.Time, p.Memory)
+	}
+	return ps
+}
+
+func bwddatatraceperfs(ps []ConvBwdDataAlgoPerf) []traceperf {
+	perfs := make([]traceperf, len(ps))
+	for i := range ps {
+		algo, time, mem := ps[i].Get()
+		perfs[i] = traceperf{Algo: int32(algo), Time: time, Memory: mem}
+	}
+	return perfs
+}
+
+func bwddataperfs(perfs []traceperf) []ConvBwdDataAlgoPerf {
+	ps := make([]ConvBwdDataAlgoPerf, len(perfs))
+	for i, p := range perfs {
+		ps[i] = newConvBwdDataAlgoPerf(ConvBwdDataAlgorithm(p.Algo), p.Time, p.Memory)
+	}
+	return ps
+}
+
+func bwdweighttraceperfs(ps []ConvBwdWeightAlgoPerf) []traceperf {
+	perfs := make([]traceperf, len(ps))
+	for i := range ps {
+		algo, time, mem := ps[i].Get()
+		perfs[i] = traceperf{Algo: int32(algo), Time: time, Memory: mem}
+	}
+	return perfs
+}
+
+func bwdweightperfs(perfs []traceperf) []ConvBwdWeightAlgoPerf {
+	ps := make([]ConvBwdWeightAlgoPerf, len(perfs))
+	for i, p := range perfs {
+		ps[i] = newConvBwdWeightAlgoPerf(ConvBwdWeightsAlgorithm(p.Algo), p.Time, p.Memory)
+	}
+	return ps
+}
@@ -0,0 +1,58 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestTraceReplay(t *testing.T) {
+	run := func(h *miopen.Handle, n int32) error {
+		xD := tensor(t, 1, n, 1, 1)
+		a, err := miopen.CreateActivationDescriptor()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var mode miopen.ActivationMode
+		if err = a.Set(mode.Relu(), 0, 0, 0); err != nil {
+			t.Fatal(err)
+		}
+		return a.Forward(h, 1, xD, make(floats, n), 0, xD, make(floats, n))
+	}
+	h := miopen.CreateHandle()
+	rec := h.Record()
+	if err := run(h, 4); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := rec.WriteTrace(&buf); err != nil {
+		t.Fatal(err)
+	}
+	trace, err := miopen.ReadTrace(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trace) != 1 || trace[0].Func != "ActivationForward" {
+		t.Fatal("unexpected trace", trace)
+	}
+	h.Replay(trace)
+	if err = run(h, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Backend().(*miopen.Tracer).Done(); err != nil {
+		t.Fatal(err)
+	}
+	rep := h.Replay(trace)
+	var rerr *miopen.ReplayError
+	if err = run(h, 3); !errors.As(err, &rerr) || rerr.Index != 0 {
+		t.Fatal("expected a replay error, got", err)
+	}
+	if rep.Done() == nil {
+		t.Fatal("Done should report the mismatch")
+	}
+}
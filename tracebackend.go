@@ -0,0 +1,630 @@
+package miopen
+
+import "github.com/dereklstinson/cutil"
+
+//EnableProfiling passes enable to the traced backend if it has its own profiling.  It isn't traced.
+func (t *Tracer) EnableProfiling(enable bool) error {
+	if p, ok := t.next.(profiler); ok {
+		return p.EnableProfiling(enable)
+	}
+	return statusNotImplemented.error("(*Tracer)EnableProfiling")
+}
+
+//GetKernelTime returns the kernel time of the traced backend if it has its own profiling.  It isn't traced.
+func (t *Tracer) GetKernelTime() (time float32, err error) {
+	if p, ok := t.next.(profiler); ok {
+		return p.GetKernelTime()
+	}
+	return 0, statusNotImplemented.error("(*Tracer)GetKernelTime")
+}
+
+func (t *Tracer) SetTensor(tD *TensorD, tmem cutil.Mem, alpha float64) error {
+	return t.do(newcall("SetTensor",
+		descarg("tD", tD), memarg("t", tmem, tD), arg("alpha", alpha)),
+		func(b Backend) error {
+			return b.SetTensor(tD, tmem, alpha)
+		})
+}
+
+func (t *Tracer) ScaleTensor(tD *TensorD, tmem cutil.Mem, alpha float64) error {
+	return t.do(newcall("ScaleTensor",
+		descarg("tD", tD), memarg("t", tmem, tD), arg("alpha", alpha)),
+		func(b Backend) error {
+			return b.ScaleTensor(tD, tmem, alpha)
+		})
+}
+
+func (t *Tracer) LogTensor(tD *TensorD, tmem cutil.Mem) error {
+	return t.do(newcall("LogTensor",
+		descarg("tD", tD), memarg("t", tmem, tD)),
+		func(b Backend) error {
+			return b.LogTensor(tD, tmem)
+		})
+}
+
+func (t *Tracer) TransformTensor(alpha float64, xD *TensorD, x cutil.Mem, beta float64, yD *TensorD, y cutil.Mem) error {
+	return t.do(newcall("TransformTensor",
+		arg("alpha", alpha), descarg("xD", xD), memarg("x", x, xD),
+		arg("beta", beta), descarg("yD", yD), memarg("y", y, yD)),
+		func(b Backend) error {
+			return b.TransformTensor(alpha, xD, x, beta, yD, y)
+		})
+}
+
+func (t *Tracer) OpTensor(op OpTensorOp,
+	alpha float64, aD *TensorD, a cutil.Mem,
+	alpha2 float64, bD *TensorD, bmem cutil.Mem,
+	beta float64, cD *TensorD, c cutil.Mem) error {
+	return t.do(newcall("OpTensor", arg("op", op),
+		arg("alpha", alpha), descarg("aD", aD), memarg("a", a, aD),
+		arg("alpha2", alpha2), descarg("bD", bD), memarg("b", bmem, bD),
+		arg("beta", beta), descarg("cD", cD), memarg("c", c, cD)),
+		func(b Backend) error {
+			return b.OpTensor(op, alpha, aD, a, alpha2, bD, bmem, beta, cD, c)
+		})
+}
+
+func (t *Tracer) ConvolutionForwardGetWorkSpaceSize(c *ConvolutionD, wD, xD, yD *TensorD) (wspaceSIB uint, err error) {
+	err = t.do(newcall("ConvolutionForwardGetWorkSpaceSize",
+		descarg("c", c), descarg("wD", wD), descarg("xD", xD), descarg("yD", yD)),
+		func(b Backend) (err error) {
+			wspaceSIB, err = b.ConvolutionForwardGetWorkSpaceSize(c, wD, xD, yD)
+			return err
+		}, result{"wspaceSIB", &wspaceSIB})
+	return wspaceSIB, err
+}
+
+func (t *Tracer) FindConvolutionForwardAlgorithm(c *ConvolutionD,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvFwdAlgoPerf, error) {
+	var perfs []traceperf
+	err := t.do(newcall("FindConvolutionForwardAlgorithm", descarg("c", c),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("wD", wD), memarg("w", w, wD),
+		descarg("yD", yD), memarg("y", y, yD),
+		wspacearg("wspace", wspace, wspaceSIB),
+		arg("opts", opts)),
+		func(b Backend) error {
+			ps, err := b.FindConvolutionForwardAlgorithm(c, xD, x, wD, w, yD, y, wspace, wspaceSIB, opts)
+			perfs = fwdtraceperfs(ps)
+			return err
+		}, result{"perfs", &perfs})
+	return fwdperfs(perfs), err
+}
+
+func (t *Tracer) ConvolutionForward(c *ConvolutionD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvFwdAlgorithm,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	//algorithms are recorded by value, like the Algo of the perfs in the results of Find
+	return t.do(newcall("ConvolutionForward", descarg("c", c), arg("alpha", alpha),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("wD", wD), memarg("w", w, wD),
+		arg("algo", int32(algo)), arg("beta", beta),
+		descarg("yD", yD), memarg("y", y, yD),
+		wspacearg("wspace", wspace, wspaceSIB)),
+		func(b Backend) error {
+			return b.ConvolutionForward(c, alpha, xD, x, wD, w, algo, beta, yD, y, wspace, wspaceSIB)
+		})
+}
+
+func (t *Tracer) ConvolutionForwardBias(c *ConvolutionD, alpha float64,
+	bD *TensorD, bmem cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	return t.do(newcall("ConvolutionForwardBias", descarg("c", c), arg("alpha", alpha),
+		descarg("bD", bD), memarg("b", bmem, bD),
+		arg("beta", beta), descarg("yD", yD), memarg("y", y, yD)),
+		func(b Backend) error {
+			return b.ConvolutionForwardBias(c, alpha, bD, bmem, beta, yD, y)
+		})
+}
+
+func (t *Tracer) ConvolutionBiasActivationForward(c *ConvolutionD, alpha1 float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvFwdAlgorithm,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha2 float64,
+	zD *TensorD, z cutil.Mem,
+	bD *TensorD, bmem cutil.Mem,
+	a *ActivationD,
+	yD *TensorD, y cutil.Mem) error {
+	return t.do(newcall("ConvolutionBiasActivationForward", descarg("c", c), arg("alpha1", alpha1),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("wD", wD), memarg("w", w, wD),
+		arg("algo", int32(algo)), wspacearg("wspace", wspace, wspaceSIB), arg("alpha2", alpha2),
+		descarg("zD", zD), memarg("z", z, zD),
+		descarg("bD", bD), memarg("b", bmem, bD),
+		descarg("a", a), descarg("yD", yD), memarg("y", y, yD)),
+		func(b Backend) error {
+			return b.ConvolutionBiasActivationForward(c, alpha1, xD, x, wD, w, algo, wspace, wspaceSIB, alpha2, zD, z, bD, bmem, a, yD, y)
+		})
+}
+
+func (t *Tracer) ConvolutionBackwardDataGetWorkSpaceSize(c *ConvolutionD, dyD, wD, dxD *TensorD) (wspaceSIB uint, err error) {
+	err = t.do(newcall("ConvolutionBackwardDataGetWorkSpaceSize",
+		descarg("c", c), descarg("dyD", dyD), descarg("wD", wD), descarg("dxD", dxD)),
+		func(b Backend) (err error) {
+			wspaceSIB, err = b.ConvolutionBackwardDataGetWorkSpaceSize(c, dyD, wD, dxD)
+			return err
+		}, result{"wspaceSIB", &wspaceSIB})
+	return wspaceSIB, err
+}
+
+func (t *Tracer) FindConvolutionBackwardDataAlgorithm(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvBwdDataAlgoPerf, error) {
+	var perfs []traceperf
+	err := t.do(newcall("FindConvolutionBackwardDataAlgorithm", descarg("c", c),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("wD", wD), memarg("w", w, wD),
+		descarg("dxD", dxD), memarg("dx", dx, dxD),
+		wspacearg("wspace", wspace, wspaceSIB),
+		arg("opts", opts)),
+		func(b Backend) error {
+			ps, err := b.FindConvolutionBackwardDataAlgorithm(c, dyD, dy, wD, w, dxD, dx, wspace, wspaceSIB, opts)
+			perfs = bwddatatraceperfs(ps)
+			return err
+		}, result{"perfs", &perfs})
+	return bwddataperfs(perfs), err
+}
+
+func (t *Tracer) ConvolutionBackwardData(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvBwdDataAlgorithm,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	return t.do(newcall("ConvolutionBackwardData", descarg("c", c), arg("alpha", alpha),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("wD", wD), memarg("w", w, wD),
+		arg("algo", int32(algo)), arg("beta", beta),
+		descarg("dxD", dxD), memarg("dx", dx, dxD),
+		wspacearg("wspace", wspace, wspaceSIB)),
+		func(b Backend) error {
+			return b.ConvolutionBackwardData(c, alpha, dyD, dy, wD, w, algo, beta, dxD, dx, wspace, wspaceSIB)
+		})
+}
+
+func (t *Tracer) ConvolutionBackwardWeightsGetWorkSpaceSize(c *ConvolutionD, dyD, xD, dwD *TensorD) (wspaceSIB uint, err error) {
+	err = t.do(newcall("ConvolutionBackwardWeightsGetWorkSpaceSize",
+		descarg("c", c), descarg("dyD", dyD), descarg("xD", xD), descarg("dwD", dwD)),
+		func(b Backend) (err error) {
+			wspaceSIB, err = b.ConvolutionBackwardWeightsGetWorkSpaceSize(c, dyD, xD, dwD)
+			return err
+		}, result{"wspaceSIB", &wspaceSIB})
+	return wspaceSIB, err
+}
+
+func (t *Tracer) FindConvolutionBackwardWeightsAlgorithm(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	opts FindOptions) ([]ConvBwdWeightAlgoPerf, error) {
+	var perfs []traceperf
+	err := t.do(newcall("FindConvolutionBackwardWeightsAlgorithm", descarg("c", c),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("dwD", dwD), memarg("dw", dw, dwD),
+		wspacearg("wspace", wspace, wspaceSIB),
+		arg("opts", opts)),
+		func(b Backend) error {
+			ps, err := b.FindConvolutionBackwardWeightsAlgorithm(c, dyD, dy, xD, x, dwD, dw, wspace, wspaceSIB, opts)
+			perfs = bwdweighttraceperfs(ps)
+			return err
+		}, result{"perfs", &perfs})
+	return bwdweightperfs(perfs), err
+}
+
+func (t *Tracer) ConvolutionBackwardWeights(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	algo ConvBwdWeightsAlgorithm,
+	beta float64,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint) error {
+	return t.do(newcall("ConvolutionBackwardWeights", descarg("c", c), arg("alpha", alpha),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("xD", xD), memarg("x", x, xD),
+		arg("algo", int32(algo)), arg("beta", beta),
+		descarg("dwD", dwD), memarg("dw", dw, dwD),
+		wspacearg("wspace", wspace, wspaceSIB)),
+		func(b Backend) error {
+			return b.ConvolutionBackwardWeights(c, alpha, dyD, dy, xD, x, algo, beta, dwD, dw, wspace, wspaceSIB)
+		})
+}
+
+func (t *Tracer) ConvolutionBackwardBias(c *ConvolutionD, alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	beta float64,
+	dbD *TensorD, db cutil.Mem) error {
+	return t.do(newcall("ConvolutionBackwardBias", descarg("c", c), arg("alpha", alpha),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		arg("beta", beta), descarg("dbD", dbD), memarg("db", db, dbD)),
+		func(b Backend) error {
+			return b.ConvolutionBackwardBias(c, alpha, dyD, dy, beta, dbD, db)
+		})
+}
+
+func (t *Tracer) ActivationForward(a *ActivationD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	return t.do(newcall("ActivationForward", descarg("a", a), arg("alpha", alpha),
+		descarg("xD", xD), memarg("x", x, xD),
+		arg("beta", beta), descarg("yD", yD), memarg("y", y, yD)),
+		func(b Backend) error {
+			return b.ActivationForward(a, alpha, xD, x, beta, yD, y)
+		})
+}
+
+func (t *Tracer) ActivationBackward(a *ActivationD, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	return t.do(newcall("ActivationBackward", descarg("a", a), arg("alpha", alpha),
+		descarg("yD", yD), memarg("y", y, yD),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("xD", xD), memarg("x", x, xD),
+		arg("beta", beta), descarg("dxD", dxD), memarg("dx", dx, dxD)),
+		func(b Backend) error {
+			return b.ActivationBackward(a, alpha, yD, y, dyD, dy, xD, x, beta, dxD, dx)
+		})
+}
+
+func (t *Tracer) PoolingForward(p *PoolingD, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	dobackwards bool, wspace cutil.Mem, wspaceSIB uint) error {
+	return t.do(newcall("PoolingForward", descarg("p", p), arg("alpha", alpha),
+		descarg("xD", xD), memarg("x", x, xD),
+		arg("beta", beta), descarg("yD", yD), memarg("y", y, yD),
+		arg("dobackwards", dobackwards), wspacearg("wspace", wspace, wspaceSIB)),
+		func(b Backend) error {
+			return b.PoolingForward(p, alpha, xD, x, beta, yD, y, dobackwards, wspace, wspaceSIB)
+		})
+}
+
+func (t *Tracer) PoolingBackward(p *PoolingD, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem) error {
+	return t.do(newcall("PoolingBackward", descarg("p", p), arg("alpha", alpha),
+		descarg("yD", yD), memarg("y", y, yD),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("xD", xD), memarg("x", x, xD),
+		arg("beta", beta), descarg("dxD", dxD), memarg("dx", dx, dxD),
+		arg("wspace", wspace != nil)),
+		func(b Backend) error {
+			return b.PoolingBackward(p, alpha, yD, y, dyD, dy, xD, x, beta, dxD, dx, wspace)
+		})
+}
+
+func (t *Tracer) SoftmaxForward(alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	return t.do(newcall("SoftmaxForward", arg("alpha", alpha),
+		descarg("xD", xD), memarg("x", x, xD),
+		arg("beta", beta), descarg("yD", yD), memarg("y", y, yD)),
+		func(b Backend) error {
+			return b.SoftmaxForward(alpha, xD, x, beta, yD, y)
+		})
+}
+
+func (t *Tracer) SoftmaxBackward(alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	return t.do(newcall("SoftmaxBackward", arg("alpha", alpha),
+		descarg("yD", yD), memarg("y", y, yD),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		arg("beta", beta), descarg("dxD", dxD), memarg("dx", dx, dxD)),
+		func(b Backend) error {
+			return b.SoftmaxBackward(alpha, yD, y, dyD, dy, beta, dxD, dx)
+		})
+}
+
+func (t *Tracer) LRNForward(l *LRND, alpha float64,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	yD *TensorD, y cutil.Mem,
+	doBackwards bool, wspace cutil.Mem) error {
+	return t.do(newcall("LRNForward", descarg("l", l), arg("alpha", alpha),
+		descarg("xD", xD), memarg("x", x, xD),
+		arg("beta", beta), descarg("yD", yD), memarg("y", y, yD),
+		arg("doBackwards", doBackwards), arg("wspace", wspace != nil)),
+		func(b Backend) error {
+			return b.LRNForward(l, alpha, xD, x, beta, yD, y, doBackwards, wspace)
+		})
+}
+
+func (t *Tracer) LRNBackward(l *LRND, alpha float64,
+	yD *TensorD, y cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem) error {
+	return t.do(newcall("LRNBackward", descarg("l", l), arg("alpha", alpha),
+		descarg("yD", yD), memarg("y", y, yD),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("xD", xD), memarg("x", x, xD),
+		arg("beta", beta), descarg("dxD", dxD), memarg("dx", dx, dxD),
+		arg("wspace", wspace != nil)),
+		func(b Backend) error {
+			return b.LRNBackward(l, alpha, yD, y, dyD, dy, xD, x, beta, dxD, dx, wspace)
+		})
+}
+
+func (t *Tracer) BatchNormalizationForwardInference(bn *BatchNormD, alpha, beta float64,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	scalbiasmeanvarD *TensorD,
+	scale, bias cutil.Mem,
+	mean, variance cutil.Mem,
+	epsilon float64) error {
+	pD := scalbiasmeanvarD
+	return t.do(newcall("BatchNormalizationForwardInference", descarg("bn", bn),
+		arg("alpha", alpha), arg("beta", beta),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("yD", yD), memarg("y", y, yD),
+		descarg("scalbiasmeanvarD", pD),
+		memarg("scale", scale, pD), memarg("bias", bias, pD),
+		memarg("mean", mean, pD), memarg("variance", variance, pD),
+		arg("epsilon", epsilon)),
+		func(b Backend) error {
+			return b.BatchNormalizationForwardInference(bn, alpha, beta, xD, x, yD, y, pD, scale, bias, mean, variance, epsilon)
+		})
+}
+
+func (t *Tracer) BatchNormalizationForwardTraining(bn *BatchNormD, alpha, beta float64,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	scalbiasmeanvarD *TensorD,
+	scale, bias cutil.Mem,
+	avgfactor float64,
+	mean, variance cutil.Mem,
+	epsilon float64,
+	saveMean, saveInvariance cutil.Mem) error {
+	pD := scalbiasmeanvarD
+	return t.do(newcall("BatchNormalizationForwardTraining", descarg("bn", bn),
+		arg("alpha", alpha), arg("beta", beta),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("yD", yD), memarg("y", y, yD),
+		descarg("scalbiasmeanvarD", pD),
+		memarg("scale", scale, pD), memarg("bias", bias, pD),
+		arg("avgfactor", avgfactor),
+		memarg("mean", mean, pD), memarg("variance", variance, pD),
+		arg("epsilon", epsilon),
+		memarg("saveMean", saveMean, pD), memarg("saveInvariance", saveInvariance, pD)),
+		func(b Backend) error {
+			return b.BatchNormalizationForwardTraining(bn, alpha, beta, xD, x, yD, y, pD, scale, bias, avgfactor, mean, variance, epsilon, saveMean, saveInvariance)
+		})
+}
+
+func (t *Tracer) BatchNormalizationBackward(bn *BatchNormD, alphaDataDiff, betaDataDiff, alphaParamDiff, betaParamDiff float64,
+	xD *TensorD, x cutil.Mem,
+	dyD *TensorD, dy cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	scalebiasdiffD *TensorD,
+	scale, scalediff, biasdiff cutil.Mem,
+	epsilon float64,
+	savedMean, savedInvVariance cutil.Mem) error {
+	pD := scalebiasdiffD
+	return t.do(newcall("BatchNormalizationBackward", descarg("bn", bn),
+		arg("alphaDataDiff", alphaDataDiff), arg("betaDataDiff", betaDataDiff),
+		arg("alphaParamDiff", alphaParamDiff), arg("betaParamDiff", betaParamDiff),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("dxD", dxD), memarg("dx", dx, dxD),
+		descarg("scalebiasdiffD", pD),
+		memarg("scale", scale, pD), memarg("scalediff", scalediff, pD), memarg("biasdiff", biasdiff, pD),
+		arg("epsilon", epsilon),
+		memarg("savedMean", savedMean, pD), memarg("savedInvVariance", savedInvVariance, pD)),
+		func(b Backend) error {
+			return b.BatchNormalizationBackward(bn, alphaDataDiff, betaDataDiff, alphaParamDiff, betaParamDiff,
+				xD, x, dyD, dy, dxD, dx, pD, scale, scalediff, biasdiff, epsilon, savedMean, savedInvVariance)
+		})
+}
+
+func (t *Tracer) ConvolutionForwardGetSolutionCount(c *ConvolutionD, wD, xD, yD *TensorD) (count uint, err error) {
+	err = t.do(newcall("ConvolutionForwardGetSolutionCount", descarg("c", c), descarg("wD", wD), descarg("xD", xD), descarg("yD", yD)),
+		func(b Backend) (err error) {
+			count, err = b.ConvolutionForwardGetSolutionCount(c, wD, xD, yD)
+			return err
+		}, result{"count", &count})
+	return count, err
+}
+
+func (t *Tracer) ConvolutionForwardGetSolution(c *ConvolutionD, wD, xD, yD *TensorD, max uint) (solutions []ConvSolution, err error) {
+	err = t.do(newcall("ConvolutionForwardGetSolution", descarg("c", c), descarg("wD", wD), descarg("xD", xD), descarg("yD", yD), arg("max", max)),
+		func(b Backend) (err error) {
+			solutions, err = b.ConvolutionForwardGetSolution(c, wD, xD, yD, max)
+			return err
+		}, result{"solutions", &solutions})
+	return solutions, err
+}
+
+func (t *Tracer) ConvolutionForwardGetSolutionWorkspaceSize(c *ConvolutionD, wD, xD, yD *TensorD, solutionID uint64) (wspaceSIB uint, err error) {
+	err = t.do(newcall("ConvolutionForwardGetSolutionWorkspaceSize", descarg("c", c), descarg("wD", wD), descarg("xD", xD), descarg("yD", yD), arg("solutionID", solutionID)),
+		func(b Backend) (err error) {
+			wspaceSIB, err = b.ConvolutionForwardGetSolutionWorkspaceSize(c, wD, xD, yD, solutionID)
+			return err
+		}, result{"wspaceSIB", &wspaceSIB})
+	return wspaceSIB, err
+}
+
+func (t *Tracer) ConvolutionForwardCompileSolution(c *ConvolutionD, wD, xD, yD *TensorD, solutionID uint64) error {
+	return t.do(newcall("ConvolutionForwardCompileSolution", descarg("c", c), descarg("wD", wD), descarg("xD", xD), descarg("yD", yD), arg("solutionID", solutionID)),
+		func(b Backend) error {
+			return b.ConvolutionForwardCompileSolution(c, wD, xD, yD, solutionID)
+		})
+}
+
+func (t *Tracer) ConvolutionForwardImmediate(c *ConvolutionD,
+	wD *TensorD, w cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	yD *TensorD, y cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	return t.do(newcall("ConvolutionForwardImmediate", descarg("c", c),
+		descarg("wD", wD), memarg("w", w, wD),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("yD", yD), memarg("y", y, yD),
+		wspacearg("wspace", wspace, wspaceSIB), arg("solutionID", solutionID)),
+		func(b Backend) error {
+			return b.ConvolutionForwardImmediate(c, wD, w, xD, x, yD, y, wspace, wspaceSIB, solutionID)
+		})
+}
+
+func (t *Tracer) ConvolutionBackwardDataGetSolutionCount(c *ConvolutionD, dyD, wD, dxD *TensorD) (count uint, err error) {
+	err = t.do(newcall("ConvolutionBackwardDataGetSolutionCount", descarg("c", c), descarg("dyD", dyD), descarg("wD", wD), descarg("dxD", dxD)),
+		func(b Backend) (err error) {
+			count, err = b.ConvolutionBackwardDataGetSolutionCount(c, dyD, wD, dxD)
+			return err
+		}, result{"count", &count})
+	return count, err
+}
+
+func (t *Tracer) ConvolutionBackwardDataGetSolution(c *ConvolutionD, dyD, wD, dxD *TensorD, max uint) (solutions []ConvSolution, err error) {
+	err = t.do(newcall("ConvolutionBackwardDataGetSolution", descarg("c", c), descarg("dyD", dyD), descarg("wD", wD), descarg("dxD", dxD), arg("max", max)),
+		func(b Backend) (err error) {
+			solutions, err = b.ConvolutionBackwardDataGetSolution(c, dyD, wD, dxD, max)
+			return err
+		}, result{"solutions", &solutions})
+	return solutions, err
+}
+
+func (t *Tracer) ConvolutionBackwardDataGetSolutionWorkspaceSize(c *ConvolutionD, dyD, wD, dxD *TensorD, solutionID uint64) (wspaceSIB uint, err error) {
+	err = t.do(newcall("ConvolutionBackwardDataGetSolutionWorkspaceSize", descarg("c", c), descarg("dyD", dyD), descarg("wD", wD), descarg("dxD", dxD), arg("solutionID", solutionID)),
+		func(b Backend) (err error) {
+			wspaceSIB, err = b.ConvolutionBackwardDataGetSolutionWorkspaceSize(c, dyD, wD, dxD, solutionID)
+			return err
+		}, result{"wspaceSIB", &wspaceSIB})
+	return wspaceSIB, err
+}
+
+func (t *Tracer) ConvolutionBackwardDataCompileSolution(c *ConvolutionD, dyD, wD, dxD *TensorD, solutionID uint64) error {
+	return t.do(newcall("ConvolutionBackwardDataCompileSolution", descarg("c", c), descarg("dyD", dyD), descarg("wD", wD), descarg("dxD", dxD), arg("solutionID", solutionID)),
+		func(b Backend) error {
+			return b.ConvolutionBackwardDataCompileSolution(c, dyD, wD, dxD, solutionID)
+		})
+}
+
+func (t *Tracer) ConvolutionBackwardDataImmediate(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	dxD *TensorD, dx cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	return t.do(newcall("ConvolutionBackwardDataImmediate", descarg("c", c),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("wD", wD), memarg("w", w, wD),
+		descarg("dxD", dxD), memarg("dx", dx, dxD),
+		wspacearg("wspace", wspace, wspaceSIB), arg("solutionID", solutionID)),
+		func(b Backend) error {
+			return b.ConvolutionBackwardDataImmediate(c, dyD, dy, wD, w, dxD, dx, wspace, wspaceSIB, solutionID)
+		})
+}
+
+func (t *Tracer) ConvolutionBackwardWeightsGetSolutionCount(c *ConvolutionD, dyD, xD, dwD *TensorD) (count uint, err error) {
+	err = t.do(newcall("ConvolutionBackwardWeightsGetSolutionCount", descarg("c", c), descarg("dyD", dyD), descarg("xD", xD), descarg("dwD", dwD)),
+		func(b Backend) (err error) {
+			count, err = b.ConvolutionBackwardWeightsGetSolutionCount(c, dyD, xD, dwD)
+			return err
+		}, result{"count", &count})
+	return count, err
+}
+
+func (t *Tracer) ConvolutionBackwardWeightsGetSolution(c *ConvolutionD, dyD, xD, dwD *TensorD, max uint) (solutions []ConvSolution, err error) {
+	err = t.do(newcall("ConvolutionBackwardWeightsGetSolution", descarg("c", c), descarg("dyD", dyD), descarg("xD", xD), descarg("dwD", dwD), arg("max", max)),
+		func(b Backend) (err error) {
+			solutions, err = b.ConvolutionBackwardWeightsGetSolution(c, dyD, xD, dwD, max)
+			return err
+		}, result{"solutions", &solutions})
+	return solutions, err
+}
+
+func (t *Tracer) ConvolutionBackwardWeightsGetSolutionWorkspaceSize(c *ConvolutionD, dyD, xD, dwD *TensorD, solutionID uint64) (wspaceSIB uint, err error) {
+	err = t.do(newcall("ConvolutionBackwardWeightsGetSolutionWorkspaceSize", descarg("c", c), descarg("dyD", dyD), descarg("xD", xD), descarg("dwD", dwD), arg("solutionID", solutionID)),
+		func(b Backend) (err error) {
+			wspaceSIB, err = b.ConvolutionBackwardWeightsGetSolutionWorkspaceSize(c, dyD, xD, dwD, solutionID)
+			return err
+		}, result{"wspaceSIB", &wspaceSIB})
+	return wspaceSIB, err
+}
+
+func (t *Tracer) ConvolutionBackwardWeightsCompileSolution(c *ConvolutionD, dyD, xD, dwD *TensorD, solutionID uint64) error {
+	return t.do(newcall("ConvolutionBackwardWeightsCompileSolution", descarg("c", c), descarg("dyD", dyD), descarg("xD", xD), descarg("dwD", dwD), arg("solutionID", solutionID)),
+		func(b Backend) error {
+			return b.ConvolutionBackwardWeightsCompileSolution(c, dyD, xD, dwD, solutionID)
+		})
+}
+
+func (t *Tracer) ConvolutionBackwardWeightsImmediate(c *ConvolutionD,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	dwD *TensorD, dw cutil.Mem,
+	wspace cutil.Mem, wspaceSIB uint,
+	solutionID uint64) error {
+	return t.do(newcall("ConvolutionBackwardWeightsImmediate", descarg("c", c),
+		descarg("dyD", dyD), memarg("dy", dy, dyD),
+		descarg("xD", xD), memarg("x", x, xD),
+		descarg("dwD", dwD), memarg("dw", dw, dwD),
+		wspacearg("wspace", wspace, wspaceSIB), arg("solutionID", solutionID)),
+		func(b Backend) error {
+			return b.ConvolutionBackwardWeightsImmediate(c, dyD, dy, xD, x, dwD, dw, wspace, wspaceSIB, solutionID)
+		})
+}
+
+func (t *Tracer) GetReductionIndicesSize(rt *ReduceTensorD, aD, cD *TensorD) (indicesSIB uint, err error) {
+	err = t.do(newcall("GetReductionIndicesSize", descarg("rt", rt), descarg("aD", aD), descarg("cD", cD)),
+		func(b Backend) (err error) {
+			indicesSIB, err = b.GetReductionIndicesSize(rt, aD, cD)
+			return err
+		}, result{"indicesSIB", &indicesSIB})
+	return indicesSIB, err
+}
+
+func (t *Tracer) GetReductionWorkspaceSize(rt *ReduceTensorD, aD, cD *TensorD) (wspaceSIB uint, err error) {
+	err = t.do(newcall("GetReductionWorkspaceSize", descarg("rt", rt), descarg("aD", aD), descarg("cD", cD)),
+		func(b Backend) (err error) {
+			wspaceSIB, err = b.GetReductionWorkspaceSize(rt, aD, cD)
+			return err
+		}, result{"wspaceSIB", &wspaceSIB})
+	return wspaceSIB, err
+}
+
+func (t *Tracer) ReduceTensor(rt *ReduceTensorD,
+	indices cutil.Mem, indicesSIB uint,
+	wspace cutil.Mem, wspaceSIB uint,
+	alpha float64, aD *TensorD, a cutil.Mem,
+	beta float64, cD *TensorD, c cutil.Mem) error {
+	return t.do(newcall("ReduceTensor", descarg("rt", rt),
+		wspacearg("indices", indices, indicesSIB), wspacearg("wspace", wspace, wspaceSIB),
+		arg("alpha", alpha), descarg("aD", aD), memarg("a", a, aD),
+		arg("beta", beta), descarg("cD", cD), memarg("c", c, cD)),
+		func(b Backend) error {
+			return b.ReduceTensor(rt, indices, indicesSIB, wspace, wspaceSIB, alpha, aD, a, beta, cD, c)
+		})
+}
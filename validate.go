@@ -0,0 +1,283 @@
+package miopen
+
+import (
+	"fmt"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//The checks in this file run in go before an operation is handed to the backend.  They catch the
+//mismatched descriptors that would otherwise panic while building the C arguments, or come back from
+//MIOpen as a bare miopenStatusBadParm.
+
+//sizer is implemented by memory that knows its size in bytes.  Memory passed to an operation that
+//implements it is checked against the size of its tensor descriptor.
+type sizer interface {
+	SIB() uint
+}
+
+func checktensorset(op string, shape, stride []int32) error {
+	if len(shape) == 0 || len(shape) > miopendimmax {
+		return statusBadParm.error(fmt.Sprintf("%s: len(shape) is %d, it must be 1 to %d", op, len(shape), miopendimmax))
+	}
+	if stride != nil && len(stride) != len(shape) {
+		return statusBadParm.error(fmt.Sprintf("%s: len(stride) %d != len(shape) %d", op, len(stride), len(shape)))
+	}
+	for i := range shape {
+		if shape[i] < 1 {
+			return statusBadParm.error(fmt.Sprintf("%s: shape %v must be positive", op, shape))
+		}
+		if stride != nil && stride[i] < 1 {
+			return statusBadParm.error(fmt.Sprintf("%s: stride %v must be positive", op, stride))
+		}
+	}
+	return nil
+}
+
+func checkconvset(op string, pad, stride, dilation []int32) error {
+	if len(pad) == 0 || len(pad) != len(stride) || len(pad) != len(dilation) {
+		return statusBadParm.error(fmt.Sprintf("%s: len(pad) %d, len(stride) %d and len(dilation) %d must be equal and not zero", op, len(pad), len(stride), len(dilation)))
+	}
+	for i := range pad {
+		if pad[i] < 0 || stride[i] < 1 || dilation[i] < 1 {
+			return statusBadParm.error(fmt.Sprintf("%s: pad %v must be non-negative and stride %v, dilation %v positive", op, pad, stride, dilation))
+		}
+	}
+	return nil
+}
+
+func checkpoolingset(op string, window, pad, stride []int32) error {
+	if len(window) != 2 || len(pad) != 2 || len(stride) != 2 {
+		return statusBadParm.error(op + ": len(window)!=2 || len(pad) !=2 ||len(stride)!=2")
+	}
+	for i := range window {
+		if window[i] < 1 || pad[i] < 0 || stride[i] < 1 {
+			return statusBadParm.error(fmt.Sprintf("%s: window %v, stride %v must be positive and pad %v non-negative", op, window, stride, pad))
+		}
+	}
+	return nil
+}
+
+//check collects the first problem found with the arguments of an operation.
+type check struct {
+	op  string
+	err error
+}
+
+//tensorinfo is what a check needs from a TensorD
+type tensorinfo struct {
+	name  string
+	dtype DataType
+	shape []int32
+	ok    bool
+}
+
+func (k *check) fail(format string, a ...interface{}) {
+	if k.err == nil {
+		k.err = statusBadParm.error(k.op + ": " + fmt.Sprintf(format, a...))
+	}
+}
+
+//desc checks that d is set and returns what is needed to check it against other tensors.
+func (k *check) desc(name string, d *TensorD) tensorinfo {
+	t := tensorinfo{name: name}
+	if k.err != nil {
+		return t
+	}
+	if d == nil {
+		k.fail("%s is nil", name)
+		return t
+	}
+	var err error
+	t.dtype, t.shape, _, err = d.Get()
+	if err != nil || len(t.shape) == 0 {
+		k.fail("%s is not set", name)
+		return t
+	}
+	t.ok = true
+	return t
+}
+
+//tensor checks that d is set and that m was passed.  If m implements sizer it must hold what d describes.
+func (k *check) tensor(name string, d *TensorD, m cutil.Mem) tensorinfo {
+	t := k.desc(name, d)
+	if !t.ok {
+		return t
+	}
+	if m == nil {
+		k.fail("memory for %s is nil", name)
+		return t
+	}
+	if s, ok := m.(sizer); ok {
+		sib, err := d.GetSIB()
+		if err == nil && s.SIB() < sib {
+			k.fail("memory for %s holds %d bytes, its descriptor needs %d", name, s.SIB(), sib)
+		}
+	}
+	return t
+}
+
+//mem checks that memory needed by the operation was passed
+func (k *check) mem(name string, m cutil.Mem) {
+	if m == nil {
+		k.fail("memory for %s is nil", name)
+	}
+}
+
+//wspace checks that a workspace of wspaceSIB bytes was passed if wspaceSIB isn't zero.
+func (k *check) wspace(wspace cutil.Mem, wspaceSIB uint) {
+	if wspaceSIB == 0 {
+		return
+	}
+	if wspace == nil {
+		k.fail("wspace is nil but wspaceSIB is %d", wspaceSIB)
+		return
+	}
+	if s, ok := wspace.(sizer); ok && s.SIB() < wspaceSIB {
+		k.fail("wspace holds %d bytes, wspaceSIB is %d", s.SIB(), wspaceSIB)
+	}
+}
+
+func (k *check) samedtype(ts ...tensorinfo) {
+	for _, t := range ts[1:] {
+		if ts[0].ok && t.ok && t.dtype != ts[0].dtype {
+			k.fail("%s is %s but %s is %s", ts[0].name, ts[0].dtype.ToString(), t.name, t.dtype.ToString())
+		}
+	}
+}
+
+func (k *check) sameshape(ts ...tensorinfo) {
+	for _, t := range ts[1:] {
+		if ts[0].ok && t.ok && !comparedims(ts[0].shape, t.shape) {
+			k.fail("%s %v and %s %v have different shapes", ts[0].name, ts[0].shape, t.name, t.shape)
+		}
+	}
+}
+
+func (k *check) rank(t tensorinfo, ranks ...int) {
+	if !t.ok {
+		return
+	}
+	for _, r := range ranks {
+		if len(t.shape) == r {
+			return
+		}
+	}
+	k.fail("%s %v must have rank %v", t.name, t.shape, ranks)
+}
+
+//broadcast checks that every dim of a is equal to the dim of c or 1.
+func (k *check) broadcast(a, c tensorinfo) {
+	if !a.ok || !c.ok {
+		return
+	}
+	if _, ok := broadcastmap(c.shape, a.shape); !ok {
+		k.fail("%s %v can't be broadcast to %s %v", a.name, a.shape, c.name, c.shape)
+	}
+}
+
+//bias checks that b is 1 everywhere except for the channel dim which must match y.
+func (k *check) bias(b, y tensorinfo) {
+	if !b.ok || !y.ok {
+		return
+	}
+	if len(b.shape) != len(y.shape) || len(y.shape) < 2 {
+		k.fail("%s %v must have the rank of %s %v", b.name, b.shape, y.name, y.shape)
+		return
+	}
+	for i := range b.shape {
+		if (i == 1 && b.shape[i] != y.shape[i]) || (i != 1 && b.shape[i] != 1) {
+			k.fail("%s %v must be 1 x C(%d) x 1...", b.name, b.shape, y.shape[1])
+			return
+		}
+	}
+}
+
+//conv checks the ranks, data types and channels of a convolution with input x, weights w and output y.
+func (k *check) conv(c *ConvolutionD, x, w, y tensorinfo) {
+	if k.err != nil {
+		return
+	}
+	if c == nil {
+		k.fail("convolution descriptor is nil")
+		return
+	}
+	pad, _, _, mode, err := c.Get()
+	if err != nil {
+		k.fail("convolution descriptor is not set")
+		return
+	}
+	for _, t := range []tensorinfo{x, w, y} {
+		k.rank(t, len(pad)+2)
+	}
+	var dflg DataType
+	if x.dtype == dflg.Int8() || x.dtype == dflg.Int8x4() {
+		k.samedtype(x, w)
+	} else {
+		k.samedtype(x, w, y)
+	}
+	if k.err != nil {
+		return
+	}
+	if x.shape[0] != y.shape[0] {
+		k.fail("batch of %s %v and %s %v differ", x.name, x.shape, y.name, y.shape)
+	}
+	groups := c.groupcount()
+	var mflg ConvolutionMode
+	if mode == mflg.Transpose() {
+		if x.shape[1] != w.shape[0] || y.shape[1] != w.shape[1]*groups || w.shape[0]%groups != 0 {
+			k.fail("transpose convolution channels of %s %v, %s %v and %s %v with %d groups don't agree", x.name, x.shape, w.name, w.shape, y.name, y.shape, groups)
+		}
+		return
+	}
+	if x.shape[1] != w.shape[1]*groups || y.shape[1] != w.shape[0] {
+		k.fail("convolution channels of %s %v, %s %v and %s %v with %d groups don't agree", x.name, x.shape, w.name, w.shape, y.name, y.shape, groups)
+	}
+}
+
+//pooling checks that x and y are 4d tensors with the same N and C.
+func (k *check) pooling(p *PoolingD, x, y tensorinfo) {
+	if p == nil {
+		k.fail("pooling descriptor is nil")
+		return
+	}
+	if _, _, _, _, err := p.Get(); err != nil {
+		k.fail("pooling descriptor is not set")
+		return
+	}
+	k.rank(x, 4)
+	k.rank(y, 4)
+	k.samedtype(x, y)
+	if k.err == nil && (x.shape[0] != y.shape[0] || x.shape[1] != y.shape[1]) {
+		k.fail("N and C of %s %v and %s %v differ", x.name, x.shape, y.name, y.shape)
+	}
+}
+
+//reduce checks that c has the rank and dtype of a, with every dim equal to a or 1.
+func (k *check) reduce(r *ReduceTensorD, a, c tensorinfo) {
+	if r == nil {
+		k.fail("reduce tensor descriptor is nil")
+		return
+	}
+	k.samedtype(a, c)
+	k.broadcast(c, a)
+}
+
+//batchnorm checks the ranks and shapes of a batch normalization with input x, output y and the params descriptor p.
+func (k *check) batchnorm(b *BatchNormD, x, y, p tensorinfo) {
+	if b == nil {
+		k.fail("batchnorm descriptor is nil")
+		return
+	}
+	if _, err := b.Get(); err != nil {
+		k.fail("batchnorm descriptor is not set")
+		return
+	}
+	k.rank(x, 4, 5)
+	k.sameshape(x, y)
+	k.samedtype(x, y)
+	if k.err == nil && (len(p.shape) != len(x.shape) || p.shape[0] != 1 || p.shape[1] != x.shape[1]) {
+		k.fail("%s %v doesn't match %s %v, use DeriveBNTensorDescriptor", p.name, p.shape, x.name, x.shape)
+	}
+	k.broadcast(p, x)
+}
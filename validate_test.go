@@ -0,0 +1,57 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"errors"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestValidation(t *testing.T) {
+	h := miopen.CreateHandle()
+	tD, err := miopen.CreateTensorDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dtype miopen.DataType
+	if err = tD.Set(dtype.Float(), nil, nil); !errors.Is(err, miopen.ErrBadParm) {
+		t.Fatal("expected empty shape to be rejected, got", err)
+	}
+	aD, bD, cD := tensor(t, 1, 2, 3, 1), tensor(t, 1, 3, 1, 1), tensor(t, 1, 2, 3, 1)
+	var op miopen.OpTensorOp
+	err = miopen.OpTensor(h, op.Add(), 1, aD, make(floats, 6), 1, bD, make(floats, 3), 0, cD, make(floats, 6))
+	if !errors.Is(err, miopen.ErrBadParm) {
+		t.Fatal("expected B to fail broadcasting, got", err)
+	}
+	err = miopen.OpTensor(h, op.Add(), 1, bD, make(floats, 3), 1, aD, make(floats, 6), 0, cD, make(floats, 6))
+	if !errors.Is(err, miopen.ErrBadParm) {
+		t.Fatal("expected A to be rejected when it isn't the shape of C, got", err)
+	}
+	c, err := miopen.CreateConvolutionDescriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mode miopen.ConvolutionMode
+	if err = c.Set([]int32{0, 0}, []int32{1, 1}, []int32{1, 1}, mode.Transpose()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetGroupCount(3); err != nil {
+		t.Fatal(err)
+	}
+	xD, wD, yD := tensor(t, 1, 2, 3, 3), tensor(t, 2, 1, 1, 1), tensor(t, 1, 3, 3, 3)
+	var algo miopen.ConvFwdAlgorithm
+	algo.Direct()
+	err = c.Forward(h, 1, xD, make(floats, 18), wD, make(floats, 2), &algo, 0, yD, make(floats, 27), nil, 0)
+	if !errors.Is(err, miopen.ErrBadParm) {
+		t.Fatal("expected transpose weights not divisible by the group count to be rejected, got", err)
+	}
+	if err = c.SetTransposeOutputPadding(nil); !errors.Is(err, miopen.ErrBadParm) {
+		t.Fatal("expected empty output padding to be rejected, got", err)
+	}
+	if _, err = c.ForwardOutputDim(nil, wD); !errors.Is(err, miopen.ErrBadParm) {
+		t.Fatal("expected a nil xD to be rejected, got", err)
+	}
+}
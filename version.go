@@ -0,0 +1,175 @@
+package miopen
+
+import (
+	"fmt"
+	"sync"
+)
+
+//SemVer is a MIOpen version
+type SemVer struct {
+	Major, Minor, Patch int
+}
+
+func (v SemVer) String() string { return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch) }
+
+//Less reports if v is older than w
+func (v SemVer) Less(w SemVer) bool {
+	if v.Major != w.Major {
+		return v.Major < w.Major
+	}
+	if v.Minor != w.Minor {
+		return v.Minor < w.Minor
+	}
+	return v.Patch < w.Patch
+}
+
+//HeaderVersion returns the version of the MIOpen headers the package was built against.  The cpu build
+//returns the newest version it mirrors.
+func HeaderVersion() SemVer { return headerversion() }
+
+var library struct {
+	once    sync.Once
+	version SemVer
+	err     error
+}
+
+//Version returns the version of the MIOpen library the package is linked to with miopenGetVersion.  It can
+//be older than HeaderVersion() when the shared library was swapped after the package was built.  The cpu
+//build returns HeaderVersion().
+func Version() (SemVer, error) {
+	library.once.Do(func() {
+		library.version, library.err = libraryversion()
+	})
+	return library.version, library.err
+}
+
+//Feature is used as flags for the parts of MIOpen that only some versions have.
+//
+//	var f miopen.Feature
+//	if err := miopen.Supported(f.ImmediateMode()); err != nil {
+//		//fall back to Find
+//	}
+type Feature int32
+
+//ImmediateMode sets f and returns the flag for the Get*Solutions, Compile*Solution and *Immediate functions.
+func (f *Feature) ImmediateMode() Feature { *f = Feature(0); return *f }
+
+//FwdImplicitGEMM sets f and returns the flag for the ImplicitGEMM forward convolution algorithm.
+func (f *Feature) FwdImplicitGEMM() Feature { *f = Feature(1); return *f }
+
+//BwdImplicitGEMM sets f and returns the flag for the ImplicitGEMM backward data and backward weights
+//convolution algorithms.
+func (f *Feature) BwdImplicitGEMM() Feature { *f = Feature(2); return *f }
+
+//TransposeGEMM sets f and returns the flag for the TransposeGEMM backward data convolution algorithm.
+func (f *Feature) TransposeGEMM() Feature { *f = Feature(3); return *f }
+
+//PoolingWorkSpaceSizeV2 sets f and returns the flag for miopenPoolingGetWorkSpaceSizeV2, which sizes the
+//pooling workspace from the pooling descriptor.  Without it (*PoolingD)GetWSpaceSize sizes it from y alone.
+func (f *Feature) PoolingWorkSpaceSizeV2() Feature { *f = Feature(4); return *f }
+
+//ConvBiasActivation sets f and returns the flag for miopenConvolutionBiasActivationForward.  Without it
+//(*ConvolutionD)ForwardBiasActivation makes three calls.
+func (f *Feature) ConvBiasActivation() Feature { *f = Feature(5); return *f }
+
+//ReduceTensor sets f and returns the flag for the reduction API used by ReduceTensorD.
+func (f *Feature) ReduceTensor() Feature { *f = Feature(6); return *f }
+
+//DeterministicConvolution sets f and returns the flag for the MIOPEN_CONVOLUTION_ATTRIB_DETERMINISTIC
+//convolution attribute used by (*Handle)SetDeterministic().
+func (f *Feature) DeterministicConvolution() Feature { *f = Feature(7); return *f }
+
+//capabilities is the first MIOpen version that has each Feature
+var capabilities = []struct {
+	name  string
+	since SemVer
+}{
+	{"ImmediateMode", SemVer{2, 0, 0}},
+	{"FwdImplicitGEMM", SemVer{2, 0, 0}},
+	{"BwdImplicitGEMM", SemVer{2, 1, 0}},
+	{"TransposeGEMM", SemVer{2, 0, 0}},
+	{"PoolingWorkSpaceSizeV2", SemVer{2, 0, 1}},
+	{"ConvBiasActivation", SemVer{2, 9, 0}},
+	{"ReduceTensor", SemVer{2, 11, 0}},
+	{"DeterministicConvolution", SemVer{2, 16, 0}},
+}
+
+//All returns every Feature flag
+func (f Feature) All() []Feature {
+	all := make([]Feature, len(capabilities))
+	for i := range all {
+		all[i] = Feature(i)
+	}
+	return all
+}
+
+func (f Feature) String() string {
+	if f < 0 || int(f) >= len(capabilities) {
+		return fmt.Sprintf("Feature(%d)", int32(f))
+	}
+	return capabilities[f].name
+}
+
+//Since returns the first MIOpen version that has f
+func (f Feature) Since() SemVer {
+	if f < 0 || int(f) >= len(capabilities) {
+		return SemVer{}
+	}
+	return capabilities[f].since
+}
+
+//UnsupportedError is returned when an operation needs a Feature that the MIOpen headers the package was
+//built against, or the MIOpen library it is linked to, don't have.
+//
+//errors.Is(err, ErrUnsupportedOp) is true for an *UnsupportedError.
+type UnsupportedError struct {
+	Feature Feature
+	Headers SemVer //Headers is HeaderVersion()
+	Library SemVer //Library is Version(), or the zero SemVer if it couldn't be read
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s needs MIOpen %s, the package was built against %s headers and is linked to %s",
+		e.Feature, e.Feature.Since(), e.Headers, e.Library)
+}
+
+//Is reports if target is ErrUnsupportedOp
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupportedOp
+}
+
+//Supported returns nil if both the headers the package was built against and the library it is linked
+//to have f, and an *UnsupportedError if not.
+func Supported(f Feature) error {
+	headers := HeaderVersion()
+	library, err := Version()
+	if err != nil {
+		library = SemVer{}
+	}
+	if headers.Less(f.Since()) || library.Less(f.Since()) {
+		return &UnsupportedError{Feature: f, Headers: headers, Library: library}
+	}
+	return nil
+}
+
+//supports fails with an *UnsupportedError if f isn't Supported
+func (k *check) supports(f Feature) {
+	if k.err != nil {
+		return
+	}
+	k.err = Supported(f)
+}
+
+//algorithm fails with an *UnsupportedError if the value algo of a convolution algorithm flag needs a
+//Feature that isn't Supported.  ImplicitGEMM is 5 and TransposeGEMM 4 in every algorithm type.
+func (k *check) algorithm(algo int32, backward bool) {
+	var f Feature
+	switch {
+	case algo == implicitgemm && backward:
+		k.supports(f.BwdImplicitGEMM())
+	case algo == implicitgemm:
+		k.supports(f.FwdImplicitGEMM())
+	case algo == transposegemm && backward:
+		k.supports(f.TransposeGEMM())
+	}
+}
@@ -0,0 +1,31 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"errors"
+	"testing"
+
+	miopen "github.com/dereklstinson/migo"
+)
+
+func TestVersion(t *testing.T) {
+	v, err := miopen.Version()
+	if err != nil || v != miopen.HeaderVersion() {
+		t.Fatal("cpu Version()", v, err, "differs from HeaderVersion()", miopen.HeaderVersion())
+	}
+	var f miopen.Feature
+	for _, feature := range f.All() {
+		if err = miopen.Supported(feature); err != nil {
+			t.Error(feature, err)
+		}
+	}
+	err = &miopen.UnsupportedError{Feature: f.BwdImplicitGEMM(), Headers: miopen.SemVer{Major: 2}, Library: miopen.SemVer{Major: 2}}
+	if !errors.Is(err, miopen.ErrUnsupportedOp) || errors.Is(err, miopen.ErrBadParm) {
+		t.Error("UnsupportedError doesn't match ErrUnsupportedOp")
+	}
+	if !(miopen.SemVer{Major: 2}).Less(f.BwdImplicitGEMM().Since()) {
+		t.Error("BwdImplicitGEMM is in MIOpen", f.BwdImplicitGEMM().Since())
+	}
+}
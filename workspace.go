@@ -0,0 +1,162 @@
+package miopen
+
+import (
+	"sync"
+
+	"github.com/dereklstinson/cutil"
+)
+
+//Workspace is a scratch buffer shared by the operations run on a Handle.  It grows to the largest size asked
+//for and is reused after that, so layers don't need to hold their own workspaces.
+//
+//Operations on a handle run in order on its stream, so one buffer can be used by all of them.  Don't share a
+//Workspace between handles that run at the same time.
+type Workspace struct {
+	mux sync.Mutex
+	a   Allocator
+	mem cutil.Mem
+	sib uint
+}
+
+//NewWorkspace returns an empty workspace that allocates its buffer with a.  If a is nil device memory is
+//allocated with hipMalloc (host memory in the cpu build).
+func NewWorkspace(a Allocator) *Workspace {
+	if a == nil {
+		a = defaultallocator()
+	}
+	return &Workspace{a: a}
+}
+
+//Get returns the buffer after growing it to hold at least sib bytes.  If sib is 0 nil is returned.
+//
+//The memory returned is only valid until the next call to Get or Free.
+func (w *Workspace) Get(sib uint) (cutil.Mem, error) {
+	if sib == 0 {
+		return nil, nil
+	}
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if sib <= w.sib {
+		return w.mem, nil
+	}
+	if err := w.free(); err != nil {
+		return nil, err
+	}
+	mem, err := w.a.Allocate(sib)
+	if err != nil {
+		return nil, err
+	}
+	if mem == nil {
+		return nil, statusAllocFailed.error("(*Workspace)Get()")
+	}
+	w.mem, w.sib = mem, sib
+	return w.mem, nil
+}
+
+//SIB returns the size in bytes of the buffer
+func (w *Workspace) SIB() uint {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return w.sib
+}
+
+//Free gives the buffer back to the allocator.  The workspace can still be used, and will allocate again when needed.
+func (w *Workspace) Free() error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return w.free()
+}
+
+func (w *Workspace) free() error {
+	if w.mem == nil {
+		return nil
+	}
+	err := w.a.Free(w.mem)
+	w.mem, w.sib = nil, 0
+	return err
+}
+
+//Workspace returns the workspace used by the Managed operations run on h.  One is made with NewWorkspace(nil)
+//the first time it is needed.  It is safe to call from more than one goroutine.
+func (h *Handle) Workspace() *Workspace {
+	h.wsmux.Lock()
+	defer h.wsmux.Unlock()
+	if h.ws == nil {
+		h.ws, h.ownsws = NewWorkspace(nil), true
+	}
+	return h.ws
+}
+
+//SetWorkspace sets the workspace used by the Managed operations run on h.  If w is nil a new one will be made
+//when it is needed.
+//
+//A workspace made by h is freed when h is destroyed, one passed to SetWorkspace is not.
+func (h *Handle) SetWorkspace(w *Workspace) {
+	h.wsmux.Lock()
+	defer h.wsmux.Unlock()
+	h.ws, h.ownsws = w, false
+}
+
+//ForwardManaged is Forward with a workspace from h.Workspace() sized by GetFwdWorkspaceSize.
+func (c *ConvolutionD) ForwardManaged(h *Handle,
+	alpha float64,
+	xD *TensorD, x cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo *ConvFwdAlgorithm,
+	beta float64,
+	yD *TensorD, y cutil.Mem) error {
+	wspaceSIB, err := c.GetFwdWorkspaceSize(h, wD, xD, yD)
+	if err != nil {
+		return err
+	}
+	wspace, err := h.Workspace().Get(wspaceSIB)
+	if err != nil {
+		return err
+	}
+	return c.Forward(h, alpha, xD, x, wD, w, algo, beta, yD, y, wspace, wspaceSIB)
+}
+
+//BackwardDataManaged is BackwardData with a workspace from h.Workspace() sized by GetBwdDataWorkspaceSize.
+func (c *ConvolutionD) BackwardDataManaged(h *Handle,
+	alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	wD *TensorD, w cutil.Mem,
+	algo ConvBwdDataAlgorithm,
+	beta float64,
+	dxD *TensorD, dx cutil.Mem) error {
+	wspaceSIB, err := c.GetBwdDataWorkspaceSize(h, dyD, wD, dxD)
+	if err != nil {
+		return err
+	}
+	wspace, err := h.Workspace().Get(wspaceSIB)
+	if err != nil {
+		return err
+	}
+	return c.BackwardData(h, alpha, dyD, dy, wD, w, algo, beta, dxD, dx, wspace, wspaceSIB)
+}
+
+//BackwardWeightsManaged is BackwardWeights with a workspace from h.Workspace() sized by GetBwdWeightsWorkspaceSize.
+func (c *ConvolutionD) BackwardWeightsManaged(h *Handle,
+	alpha float64,
+	dyD *TensorD, dy cutil.Mem,
+	xD *TensorD, x cutil.Mem,
+	algo ConvBwdWeightsAlgorithm,
+	beta float64,
+	dwD *TensorD, dw cutil.Mem) error {
+	wspaceSIB, err := c.GetBwdWeightsWorkspaceSize(h, dyD, xD, dwD)
+	if err != nil {
+		return err
+	}
+	wspace, err := h.Workspace().Get(wspaceSIB)
+	if err != nil {
+		return err
+	}
+	return c.BackwardWeights(h, alpha, dyD, dy, xD, x, algo, beta, dwD, dw, wspace, wspaceSIB)
+}
+
+//There are no Managed versions of the pooling and LRN operations.  Their workspace isn't scratch memory, it
+//carries what Forward saved to Backward, so it can't be shared with other layers.  Size it with
+//(*PoolingD)GetWSpaceSize() or (*LRND)GetWorkSpaceSize() and keep it with the layer.
+//
+//The same goes for RNN training, where the workspace is used from ForwardTraining to BackwardData and
+//BackwardWeights.  Only (*RNND)ForwardInferenceManaged() uses the shared workspace.
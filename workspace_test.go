@@ -0,0 +1,67 @@
+//go:build nomiopen || cpu
+// +build nomiopen cpu
+
+package miopen_test
+
+import (
+	"testing"
+
+	"github.com/dereklstinson/cutil"
+	miopen "github.com/dereklstinson/migo"
+)
+
+//countingallocator counts the bytes allocated and freed
+type countingallocator struct {
+	allocated, freed uint
+}
+
+func (a *countingallocator) Allocate(sib uint) (cutil.Mem, error) {
+	a.allocated += sib
+	return make(floats, (sib+3)/4), nil
+}
+
+func (a *countingallocator) Free(m cutil.Mem) error {
+	a.freed += uint(len(m.(floats)) * 4)
+	return nil
+}
+
+func TestWorkspace(t *testing.T) {
+	a := new(countingallocator)
+	ws := miopen.NewWorkspace(a)
+	for _, sib := range []uint{16, 8, 0, 16, 64} {
+		if _, err := ws.Get(sib); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a.allocated != 80 || a.freed != 16 || ws.SIB() != 64 {
+		t.Fatal("expected the workspace to grow twice, got", a.allocated, "bytes allocated", a.freed, "freed and", ws.SIB(), "held")
+	}
+	if err := ws.Free(); err != nil || a.freed != 80 {
+		t.Fatal("expected the workspace to be freed", a.freed, err)
+	}
+
+	h := miopen.CreateHandle()
+	h.SetWorkspace(ws)
+	c, xD, wD, yD := convfixture(t)
+	var algo miopen.ConvFwdAlgorithm
+	algo.Direct()
+	y := make(floats, 4)
+	x, w := convdata()
+	if err := c.ForwardManaged(h, 1, xD, x, wD, w, &algo, 0, yD, y); err != nil {
+		t.Fatal(err)
+	}
+	if y[0] != 6 || y[3] != 14 {
+		t.Fatal("unexpected output", y)
+	}
+
+	h = miopen.CreateHandle()
+	workspaces := make(chan *miopen.Workspace, 8)
+	for i := 0; i < cap(workspaces); i++ {
+		go func() { workspaces <- h.Workspace() }()
+	}
+	for i := 0; i < cap(workspaces); i++ {
+		if w := <-workspaces; w != h.Workspace() {
+			t.Fatal("expected every goroutine to get the same workspace")
+		}
+	}
+}